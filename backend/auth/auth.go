@@ -0,0 +1,560 @@
+// Package auth API 인증(정적 토큰, Basic 인증, OIDC JWT)과 역할 기반 접근 제어
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/yaml.v3"
+)
+
+// 역할 (뒤로 갈수록 상위 역할이며 하위 역할의 권한을 포함)
+const (
+	RoleViewer   = "viewer"
+	RoleProducer = "producer"
+	RoleOperator = "operator"
+	RoleAdmin    = "admin"
+)
+
+// roleRanks 역할별 순위
+var roleRanks = map[string]int{
+	RoleViewer:   1,
+	RoleProducer: 2,
+	RoleOperator: 3,
+	RoleAdmin:    4,
+}
+
+// principalKey gin 컨텍스트에 인증된 사용자를 저장하는 키
+const principalKey = "auth.principal"
+
+// Grant 역할 부여 (Topics가 비어 있으면 모든 토픽과 클러스터 범위)
+type Grant struct {
+	Role string `json:"role"`
+	// Topics 역할이 적용되는 토픽 이름 정규식
+	Topics []string `json:"topics,omitempty"`
+
+	topicRegexps []*regexp.Regexp
+}
+
+// StaticToken 정적 API 토큰
+type StaticToken struct {
+	Name   string  `json:"name"`
+	Token  string  `json:"token"`
+	Grants []Grant `json:"grants"`
+}
+
+// Config 인증/권한 설정 (AUTH_CONFIG 파일)
+type Config struct {
+	Tokens []StaticToken `json:"tokens"`
+	// BasicAuthFile "사용자:bcrypt 해시" 형식 파일 (htpasswd -B)
+	BasicAuthFile string      `json:"basicAuthFile"`
+	OIDC          *OIDCConfig `json:"oidc"`
+	// Users 사용자 이름(Basic 인증 사용자, OIDC username 클레임)별 역할
+	Users map[string][]Grant `json:"users"`
+	// Groups OIDC 역할 클레임 값별 역할 (매핑에 없는 클레임 값은 역할을 부여하지 않음)
+	Groups map[string][]Grant `json:"groups"`
+
+	passwords map[string][]byte
+	oidc      *oidcVerifier
+}
+
+// Principal 인증된 사용자
+type Principal struct {
+	Name   string  `json:"name"`
+	Method string  `json:"method"` // token, basic, oidc, anonymous
+	Grants []Grant `json:"grants"`
+}
+
+// TopicSource 요청에서 권한 검사 대상 토픽을 추출하는 함수
+type TopicSource func(c *gin.Context) string
+
+// TopicsSource 요청에서 권한 검사 대상 토픽 목록을 추출하는 함수 (여러 토픽에 쓰는 요청용)
+type TopicsSource func(c *gin.Context) []string
+
+var (
+	// config 로드된 설정 (nil이면 인증 비활성화)
+	config *Config
+
+	// dummyHash 없는 사용자에 대한 비교용 bcrypt 해시
+	dummyHash     []byte
+	dummyHashOnce sync.Once
+)
+
+// Load YAML/JSON 인증 설정 로드
+func Load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var cfg Config
+	if err := decode(data, &cfg); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	for i := range cfg.Tokens {
+		if cfg.Tokens[i].Token == "" || cfg.Tokens[i].Name == "" {
+			return fmt.Errorf("tokens[%d]: name and token are required", i)
+		}
+		if err := compileGrants(cfg.Tokens[i].Grants); err != nil {
+			return fmt.Errorf("tokens[%d]: %w", i, err)
+		}
+	}
+	for name, grants := range cfg.Users {
+		if err := compileGrants(grants); err != nil {
+			return fmt.Errorf("users.%s: %w", name, err)
+		}
+	}
+	for name, grants := range cfg.Groups {
+		if err := compileGrants(grants); err != nil {
+			return fmt.Errorf("groups.%s: %w", name, err)
+		}
+	}
+
+	if cfg.BasicAuthFile != "" {
+		if cfg.passwords, err = loadPasswords(cfg.BasicAuthFile); err != nil {
+			return fmt.Errorf("basicAuthFile: %w", err)
+		}
+	}
+
+	if cfg.OIDC != nil {
+		if cfg.oidc, err = newOIDCVerifier(cfg.OIDC); err != nil {
+			return fmt.Errorf("oidc: %w", err)
+		}
+	}
+
+	config = &cfg
+	return nil
+}
+
+// Enabled 인증 활성화 여부
+func Enabled() bool {
+	return config != nil
+}
+
+// Authenticate 요청의 자격 증명을 확인해 사용자를 컨텍스트에 저장 (인증 비활성화 시 admin 익명 사용자)
+// queryTokenRoutes 헤더를 보낼 수 없는 WebSocket 라우트만 access_token 쿼리를 허용 (예: "/api/consume/ws")
+func Authenticate(queryTokenRoutes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if config == nil {
+			c.Set(principalKey, &Principal{
+				Name:   "anonymous",
+				Method: "anonymous",
+				Grants: []Grant{{Role: RoleAdmin}},
+			})
+			c.Next()
+			return
+		}
+
+		allowQuery := false
+		for _, route := range queryTokenRoutes {
+			if c.FullPath() == route {
+				allowQuery = true
+				break
+			}
+		}
+
+		principal, err := config.authenticate(c.Request, allowQuery)
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer realm="kafka-monitor"`)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": fmt.Sprintf("Unauthorized: %v", err),
+			})
+			return
+		}
+
+		c.Set(principalKey, principal)
+		c.Next()
+	}
+}
+
+// Require 최소 역할 검사 (topic이 있으면 해당 토픽에 대한 역할, 없으면 클러스터 범위 역할)
+func Require(role string, topic ...TopicSource) gin.HandlerFunc {
+	if _, ok := roleRanks[role]; !ok {
+		panic("auth: unknown role " + role)
+	}
+
+	return func(c *gin.Context) {
+		principal := PrincipalFrom(c)
+		if principal == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			return
+		}
+
+		var target string
+		for _, source := range topic {
+			if target = source(c); target != "" || c.IsAborted() {
+				break
+			}
+		}
+		if c.IsAborted() {
+			return
+		}
+
+		if !principal.Allowed(role, target) {
+			forbid(c, principal, role, target)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireEach 요청의 모든 토픽에 대해 최소 역할 검사 (토픽이 없으면 클러스터 범위 역할)
+func RequireEach(role string, topics TopicsSource) gin.HandlerFunc {
+	if _, ok := roleRanks[role]; !ok {
+		panic("auth: unknown role " + role)
+	}
+
+	return func(c *gin.Context) {
+		principal := PrincipalFrom(c)
+		if principal == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			return
+		}
+
+		targets := topics(c)
+		if c.IsAborted() {
+			return
+		}
+		if len(targets) == 0 {
+			targets = []string{""}
+		}
+		for _, target := range targets {
+			if !principal.Allowed(role, target) {
+				forbid(c, principal, role, target)
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// forbid 역할 부족 응답 (target이 비어 있으면 클러스터 범위)
+func forbid(c *gin.Context, principal *Principal, role, target string) {
+	scope := "the cluster"
+	if target != "" {
+		scope = fmt.Sprintf("topic %s", target)
+	}
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+		"error": fmt.Sprintf("Forbidden: %s requires role %s on %s", principal.Name, role, scope),
+	})
+}
+
+// PrincipalFrom 컨텍스트의 인증된 사용자 (인증 미들웨어를 거치지 않았으면 nil)
+func PrincipalFrom(c *gin.Context) *Principal {
+	v, ok := c.Get(principalKey)
+	if !ok {
+		return nil
+	}
+	return v.(*Principal)
+}
+
+// WhoAmI 현재 사용자와 역할 조회
+func WhoAmI(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"auth_enabled": Enabled(),
+		"principal":    PrincipalFrom(c),
+	})
+}
+
+// Allowed 역할 보유 여부
+//
+// 토픽 범위 요청이면 해당 토픽과 일치하는 역할이 필요하다. 클러스터 범위 요청은
+// 토픽 제한이 없는 역할이 필요하며, 조회(viewer)만은 토픽 제한 역할로도 허용한다.
+func (p *Principal) Allowed(role, topic string) bool {
+	required := roleRanks[role]
+	for _, g := range p.Grants {
+		if roleRanks[g.Role] < required {
+			continue
+		}
+		if len(g.Topics) == 0 {
+			return true
+		}
+		if topic == "" {
+			if role == RoleViewer {
+				return true
+			}
+			continue
+		}
+		for _, re := range g.topicRegexps {
+			if re.MatchString(topic) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// TopicFromParam 경로 파라미터에서 토픽 추출
+func TopicFromParam(name string) TopicSource {
+	return func(c *gin.Context) string {
+		return c.Param(name)
+	}
+}
+
+// TopicFromQuery 쿼리 파라미터에서 토픽 추출
+func TopicFromQuery(name string) TopicSource {
+	return func(c *gin.Context) string {
+		return c.Query(name)
+	}
+}
+
+// TopicFromBody JSON 본문 필드에서 토픽 추출 (본문은 핸들러가 다시 읽을 수 있도록 복원)
+//
+// 핸들러의 JSON 바인딩은 필드 이름의 대소문자를 구분하지 않으므로 같은 방식으로 찾고,
+// 대소문자만 다른 필드가 여러 개면 어느 값이 쓰일지 보장할 수 없어 400으로 중단한다.
+func TopicFromBody(field string) TopicSource {
+	return func(c *gin.Context) string {
+		fields := bodyFields(c)
+		raw, err := foldedField(fields, field)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return ""
+		}
+		var topic string
+		if raw != nil {
+			json.Unmarshal(raw, &topic)
+		}
+		return topic
+	}
+}
+
+// TopicsFromBodyArray JSON 본문 배열의 각 항목 필드에서 토픽 목록 추출 (중복 제거, 본문은 복원, 필드 이름 규칙은 TopicFromBody와 같음)
+func TopicsFromBodyArray(array, field string) TopicsSource {
+	return func(c *gin.Context) []string {
+		raw, err := foldedField(bodyFields(c), array)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return nil
+		}
+		var items []map[string]json.RawMessage
+		if raw == nil || json.Unmarshal(raw, &items) != nil {
+			return nil
+		}
+
+		var topics []string
+		seen := make(map[string]bool)
+		for _, item := range items {
+			raw, err := foldedField(item, field)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %v", array, err)})
+				return nil
+			}
+			var topic string
+			if raw != nil {
+				json.Unmarshal(raw, &topic)
+			}
+			if topic != "" && !seen[topic] {
+				seen[topic] = true
+				topics = append(topics, topic)
+			}
+		}
+		return topics
+	}
+}
+
+// bodyFields JSON 본문의 최상위 필드 (객체가 아니면 nil, 본문은 복원)
+func bodyFields(c *gin.Context) map[string]json.RawMessage {
+	if c.Request.Body == nil {
+		return nil
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil
+	}
+	return fields
+}
+
+// foldedField encoding/json 바인딩과 같이 대소문자 구분 없이 필드 값 조회 (없으면 nil, 대소문자 변형이 여럿이면 오류)
+func foldedField(fields map[string]json.RawMessage, name string) (json.RawMessage, error) {
+	var value json.RawMessage
+	var matched []string
+	for key, raw := range fields {
+		if strings.EqualFold(key, name) {
+			value = raw
+			matched = append(matched, key)
+		}
+	}
+	if len(matched) > 1 {
+		sort.Strings(matched)
+		return nil, fmt.Errorf("field %s appears more than once with different case: %s", name, strings.Join(matched, ", "))
+	}
+	return value, nil
+}
+
+// authenticate Authorization 헤더(Bearer/Basic) 또는 access_token 쿼리(allowQuery일 때만, WebSocket용)로 사용자 확인
+func (cfg *Config) authenticate(r *http.Request, allowQuery bool) (*Principal, error) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		if token := r.URL.Query().Get("access_token"); token != "" {
+			if !allowQuery {
+				return nil, errors.New("access_token query parameter is only accepted for WebSocket connections")
+			}
+			return cfg.authenticateBearer(token)
+		}
+		return nil, errors.New("missing credentials")
+	}
+
+	scheme, credentials, _ := strings.Cut(header, " ")
+	switch strings.ToLower(scheme) {
+	case "bearer":
+		return cfg.authenticateBearer(strings.TrimSpace(credentials))
+	case "basic":
+		user, password, ok := r.BasicAuth()
+		if !ok {
+			return nil, errors.New("malformed basic credentials")
+		}
+		return cfg.authenticateBasic(user, password)
+	}
+
+	return nil, fmt.Errorf("unsupported authorization scheme %q", scheme)
+}
+
+// accessTokenPattern 로그에 남지 않도록 가릴 access_token 쿼리 값
+var accessTokenPattern = regexp.MustCompile(`(access_token=)[^&\s"]*`)
+
+// RedactingWriter access_token 쿼리 값을 가린 뒤 w에 쓰는 Writer (요청 로그용)
+func RedactingWriter(w io.Writer) io.Writer {
+	return redactingWriter{w}
+}
+
+type redactingWriter struct {
+	w io.Writer
+}
+
+func (r redactingWriter) Write(p []byte) (int, error) {
+	if _, err := r.w.Write(accessTokenPattern.ReplaceAll(p, []byte("${1}REDACTED"))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// authenticateBearer 정적 토큰을 먼저 확인하고, 일치하지 않으면 OIDC JWT로 검증
+func (cfg *Config) authenticateBearer(token string) (*Principal, error) {
+	for _, t := range cfg.Tokens {
+		if subtle.ConstantTimeCompare([]byte(t.Token), []byte(token)) == 1 {
+			return &Principal{Name: t.Name, Method: "token", Grants: t.Grants}, nil
+		}
+	}
+
+	if cfg.oidc == nil {
+		return nil, errors.New("invalid token")
+	}
+
+	name, groups, err := cfg.oidc.verify(token)
+	if err != nil {
+		return nil, err
+	}
+
+	principal := &Principal{Name: name, Method: "oidc"}
+	principal.Grants = append(principal.Grants, cfg.Users[name]...)
+	for _, group := range groups {
+		principal.Grants = append(principal.Grants, cfg.Groups[group]...)
+	}
+
+	return principal, nil
+}
+
+// authenticateBasic Basic 인증 파일의 bcrypt 해시로 비밀번호 확인
+func (cfg *Config) authenticateBasic(user, password string) (*Principal, error) {
+	hash, ok := cfg.passwords[user]
+	if !ok {
+		// 사용자 존재 여부가 응답 시간으로 드러나지 않도록 비교는 항상 수행
+		dummyHashOnce.Do(func() {
+			dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy"), bcrypt.DefaultCost)
+		})
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return nil, errors.New("invalid username or password")
+	}
+	if err := bcrypt.CompareHashAndPassword(hash, []byte(password)); err != nil {
+		return nil, errors.New("invalid username or password")
+	}
+
+	return &Principal{Name: user, Method: "basic", Grants: cfg.Users[user]}, nil
+}
+
+// loadPasswords "사용자:bcrypt 해시" 형식 파일 로드 (빈 줄과 # 주석 무시)
+func loadPasswords(path string) (map[string][]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	passwords := make(map[string][]byte)
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		user, hash, ok := strings.Cut(text, ":")
+		if !ok || user == "" {
+			return nil, fmt.Errorf("line %d: expected user:hash", line)
+		}
+		if _, err := bcrypt.Cost([]byte(hash)); err != nil {
+			return nil, fmt.Errorf("line %d: password must be a bcrypt hash: %v", line, err)
+		}
+		passwords[user] = []byte(hash)
+	}
+
+	return passwords, scanner.Err()
+}
+
+// compileGrants 역할 이름 검증과 토픽 정규식 컴파일
+func compileGrants(grants []Grant) error {
+	for i := range grants {
+		if _, ok := roleRanks[grants[i].Role]; !ok {
+			return fmt.Errorf("unknown role %q (expected viewer, producer, operator or admin)", grants[i].Role)
+		}
+		for _, pattern := range grants[i].Topics {
+			re, err := regexp.Compile("^(?:" + pattern + ")$")
+			if err != nil {
+				return fmt.Errorf("topic pattern %q: %w", pattern, err)
+			}
+			grants[i].topicRegexps = append(grants[i].topicRegexps, re)
+		}
+	}
+	return nil
+}
+
+// decode YAML/JSON 문서를 JSON 태그 기준으로 디코딩 (알 수 없는 필드는 오류)
+func decode(data []byte, v interface{}) error {
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		return errors.New("empty document")
+	}
+
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
@@ -0,0 +1,348 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// jwksRefreshInterval JWKS 주기적 갱신 간격
+	jwksRefreshInterval = 10 * time.Minute
+	// jwksMinRefreshInterval 알 수 없는 kid로 인한 갱신 최소 간격
+	jwksMinRefreshInterval = time.Minute
+	// clockSkew exp/nbf 검사 허용 오차
+	clockSkew = time.Minute
+)
+
+// OIDCConfig OIDC JWT 검증 설정
+type OIDCConfig struct {
+	Issuer   string `json:"issuer"`
+	Audience string `json:"audience"`
+	JWKSURL  string `json:"jwksUrl"`
+	// UsernameClaim 사용자 이름 클레임 (기본값 sub)
+	UsernameClaim string `json:"usernameClaim"`
+	// RolesClaim 역할/그룹 클레임, 점으로 중첩 경로 지정 (예: realm_access.roles, 기본값 roles)
+	RolesClaim string `json:"rolesClaim"`
+}
+
+// oidcVerifier JWKS 캐시를 가진 JWT 검증기
+type oidcVerifier struct {
+	cfg    *OIDCConfig
+	client *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+// jwk JWKS 키 항목 (RSA, EC)
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// jwtAlgorithms 지원 서명 알고리즘별 해시
+var jwtAlgorithms = map[string]crypto.Hash{
+	"RS256": crypto.SHA256,
+	"RS384": crypto.SHA384,
+	"RS512": crypto.SHA512,
+	"ES256": crypto.SHA256,
+	"ES384": crypto.SHA384,
+	"ES512": crypto.SHA512,
+}
+
+// newOIDCVerifier 설정 검증 후 JWKS를 처음 한 번 가져옴
+func newOIDCVerifier(cfg *OIDCConfig) (*oidcVerifier, error) {
+	// audience가 없으면 같은 발급자가 다른 서비스용으로 발급한 토큰도 통과하므로 필수
+	if cfg.Issuer == "" || cfg.Audience == "" || cfg.JWKSURL == "" {
+		return nil, errors.New("issuer, audience and jwksUrl are required")
+	}
+	if cfg.UsernameClaim == "" {
+		cfg.UsernameClaim = "sub"
+	}
+	if cfg.RolesClaim == "" {
+		cfg.RolesClaim = "roles"
+	}
+
+	v := &oidcVerifier{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+	if err := v.refresh(); err != nil {
+		return nil, fmt.Errorf("fetch JWKS: %w", err)
+	}
+	return v, nil
+}
+
+// verify 서명, 발급자, 대상, 유효 기간을 검사하고 사용자 이름과 역할 클레임 값 반환
+func (v *oidcVerifier) verify(token string) (string, []string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", nil, errors.New("malformed token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return "", nil, fmt.Errorf("token header: %w", err)
+	}
+
+	hash, ok := jwtAlgorithms[header.Alg]
+	if !ok {
+		return "", nil, fmt.Errorf("unsupported token algorithm %q", header.Alg)
+	}
+
+	key, err := v.key(header.Kid)
+	if err != nil {
+		return "", nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", nil, errors.New("malformed token signature")
+	}
+
+	h := hash.New()
+	h.Write([]byte(parts[0] + "." + parts[1]))
+	digest := h.Sum(nil)
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(header.Alg, "RS") {
+			return "", nil, errors.New("token algorithm does not match key type")
+		}
+		if err := rsa.VerifyPKCS1v15(k, hash, digest, signature); err != nil {
+			return "", nil, errors.New("invalid token signature")
+		}
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if !strings.HasPrefix(header.Alg, "ES") || len(signature) != 2*size {
+			return "", nil, errors.New("token algorithm does not match key type")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(k, digest, r, s) {
+			return "", nil, errors.New("invalid token signature")
+		}
+	default:
+		return "", nil, errors.New("unsupported key type")
+	}
+
+	var claims map[string]interface{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return "", nil, fmt.Errorf("token claims: %w", err)
+	}
+
+	if iss, _ := claims["iss"].(string); iss != v.cfg.Issuer {
+		return "", nil, fmt.Errorf("unexpected token issuer %q", iss)
+	}
+	if !containsClaim(claims["aud"], v.cfg.Audience) {
+		return "", nil, errors.New("token audience mismatch")
+	}
+
+	now := time.Now()
+	exp, ok := claims["exp"].(float64)
+	if !ok || now.After(time.Unix(int64(exp), 0).Add(clockSkew)) {
+		return "", nil, errors.New("token expired")
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(clockSkew).Before(time.Unix(int64(nbf), 0)) {
+		return "", nil, errors.New("token not yet valid")
+	}
+
+	name, _ := claims[v.cfg.UsernameClaim].(string)
+	if name == "" {
+		return "", nil, fmt.Errorf("token has no %s claim", v.cfg.UsernameClaim)
+	}
+
+	return name, stringsClaim(lookupClaim(claims, v.cfg.RolesClaim)), nil
+}
+
+// key kid에 해당하는 공개키 (없거나 캐시가 오래됐으면 JWKS 갱신)
+func (v *oidcVerifier) key(kid string) (crypto.PublicKey, error) {
+	v.mu.Lock()
+	key, ok := v.keys[kid]
+	stale := time.Since(v.fetchedAt) > jwksRefreshInterval
+	canRefresh := time.Since(v.fetchedAt) > jwksMinRefreshInterval
+	v.mu.Unlock()
+
+	if ok && !stale {
+		return key, nil
+	}
+
+	if stale || canRefresh {
+		if err := v.refresh(); err != nil && !ok {
+			return nil, fmt.Errorf("fetch JWKS: %w", err)
+		}
+		v.mu.Lock()
+		key, ok = v.keys[kid]
+		v.mu.Unlock()
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key, nil
+}
+
+// refresh JWKS 조회 후 키 캐시 교체
+func (v *oidcVerifier) refresh() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.cfg.JWKSURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return err
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = key
+	}
+
+	v.mu.Lock()
+	v.keys = keys
+	v.fetchedAt = time.Now()
+	v.mu.Unlock()
+
+	return nil
+}
+
+// publicKey JWK를 공개키로 변환
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
+
+// decodeSegment base64url JWT 세그먼트를 JSON으로 디코딩
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// lookupClaim 점으로 구분된 경로의 클레임 값
+func lookupClaim(claims map[string]interface{}, path string) interface{} {
+	var current interface{} = claims
+	for _, key := range strings.Split(path, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = m[key]
+	}
+	return current
+}
+
+// stringsClaim 문자열 또는 문자열 배열 클레임을 목록으로 변환
+func stringsClaim(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// containsClaim 문자열 또는 배열 클레임에 값이 포함되는지 확인
+func containsClaim(value interface{}, target string) bool {
+	for _, v := range stringsClaim(value) {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"strings"
+	"time"
+
+	"backend/handlers"
+)
+
+// runGitOps GitOps CLI 서브커맨드 실행 (plan, apply), 종료 코드 반환
+//
+//	main gitops plan  -f topics.yaml [-allow-delete] [-json]
+//	main gitops apply -f topics.yaml [-allow-delete] [-approve <plan_id>]
+func runGitOps(args []string) int {
+	if len(args) == 0 || (args[0] != "plan" && args[0] != "apply") {
+		fmt.Fprintln(os.Stderr, "usage: main gitops <plan|apply> -f <file> [-allow-delete] [-approve <plan_id>] [-json]")
+		return 2
+	}
+	command := args[0]
+
+	flags := flag.NewFlagSet("gitops "+command, flag.ContinueOnError)
+	file := flags.String("f", "", "원하는 상태 파일 (YAML/JSON, - 는 표준 입력)")
+	allowDelete := flags.Bool("allow-delete", false, "정의되지 않은 토픽/설정/ACL 삭제 포함")
+	approve := flags.String("approve", "", "적용할 plan_id (생략하면 계획 확인 후 입력, -f - 이면 필수)")
+	asJSON := flags.Bool("json", false, "계획을 JSON으로 출력")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if *file == "" {
+		fmt.Fprintln(os.Stderr, "-f is required")
+		return 2
+	}
+	// 표준 입력은 상태 파일을 읽느라 모두 소비되므로 대화형 승인을 받을 수 없음
+	if command == "apply" && *file == "-" && *approve == "" {
+		fmt.Fprintln(os.Stderr, "-approve is required with -f - (run plan first to get the plan id)")
+		return 2
+	}
+
+	var data []byte
+	var err error
+	if *file == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(*file)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to read %s: %v\n", *file, err)
+		return 1
+	}
+
+	state, err := handlers.ParseDesiredState(data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid desired state: %v\n", err)
+		return 1
+	}
+
+	plan, err := handlers.BuildGitOpsPlan(state, *allowDelete)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to build plan: %v\n", err)
+		return 1
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(plan)
+	} else {
+		printGitOpsPlan(plan)
+	}
+
+	if command == "plan" {
+		return 0
+	}
+
+	if len(plan.Errors) > 0 {
+		fmt.Fprintln(os.Stderr, "plan contains changes that cannot be applied")
+		return 1
+	}
+	if len(plan.Changes) == 0 {
+		fmt.Println("Nothing to apply.")
+		return 0
+	}
+
+	// 승인 값이 없으면 계획을 보여준 뒤 plan_id 입력으로 승인
+	if *approve == "" {
+		fmt.Printf("\nType the plan id (%s) to apply: ", plan.PlanID)
+		line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		*approve = strings.TrimSpace(line)
+	}
+	if *approve != plan.PlanID {
+		fmt.Fprintln(os.Stderr, "plan not approved (plan id does not match); nothing applied")
+		return 1
+	}
+
+	start := time.Now()
+	results := handlers.ApplyGitOpsPlan(plan)
+
+	failed := 0
+	for _, r := range results {
+		target := r.Topic
+		if r.ACL != nil {
+			target = fmt.Sprintf("%s %s", r.ACL.Principal, r.ACL.ResourceName)
+		}
+		if r.Status == "failed" {
+			failed++
+			fmt.Printf("FAILED  %s %s: %s\n", r.Action, target, r.Error)
+			continue
+		}
+		fmt.Printf("applied %s %s\n", r.Action, target)
+	}
+
+	recordGitOpsApply(plan, *file, len(results), failed, start)
+
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// recordGitOpsApply CLI 적용 결과를 감사 기록으로 남김 (실행한 OS 사용자 기준)
+func recordGitOpsApply(plan *handlers.GitOpsPlan, file string, applied, failed int, start time.Time) {
+	actor := "unknown"
+	if u, err := user.Current(); err == nil {
+		actor = u.Username
+	}
+
+	record := handlers.AuditRecord{
+		Time:       start.UTC(),
+		Actor:      actor,
+		AuthMethod: "cli",
+		Action:     "gitops.apply",
+		Target:     plan.PlanID,
+		Params: map[string]interface{}{
+			"file":        file,
+			"allowDelete": plan.AllowDelete,
+			"changes":     applied,
+		},
+		Result:     "success",
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	switch {
+	case failed == applied:
+		record.Result = "failure"
+		record.Error = fmt.Sprintf("%d changes failed", failed)
+	case failed > 0:
+		record.Result = "partial"
+		record.Error = fmt.Sprintf("%d of %d changes failed", failed, applied)
+	}
+
+	handlers.RecordAudit(record)
+}
+
+// printGitOpsPlan 계획을 diff 형식으로 출력
+func printGitOpsPlan(plan *handlers.GitOpsPlan) {
+	fmt.Printf("Plan %s: %d change(s)\n\n", plan.PlanID, len(plan.Changes))
+	if plan.Diff != "" {
+		fmt.Print(plan.Diff)
+	} else {
+		fmt.Println("No changes. Cluster matches the desired state.")
+	}
+
+	if len(plan.Unmanaged) > 0 {
+		fmt.Printf("\nNot in desired state (kept, use -allow-delete to remove):\n")
+		for _, u := range plan.Unmanaged {
+			fmt.Printf("  %s\n", u)
+		}
+	}
+
+	if len(plan.Errors) > 0 {
+		fmt.Printf("\nErrors:\n")
+		for _, e := range plan.Errors {
+			fmt.Printf("  %s\n", e)
+		}
+	}
+}
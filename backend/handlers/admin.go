@@ -2,8 +2,12 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -12,16 +16,71 @@ import (
 
 var kafkaBrokers string
 
+// kafkaClusters 복사 작업 등에서 이름으로 지정할 수 있는 다른 클러스터 (이름 → 브로커 주소, 서버 설정으로만 등록)
+var kafkaClusters map[string][]string
+
 // InitKafkaClient Kafka 클라이언트 초기화
 func InitKafkaClient(brokers string) {
 	kafkaBrokers = brokers
 }
 
+// InitKafkaClusters 다른 클러스터 허용 목록 등록 (KAFKA_CLUSTERS, "이름=브로커,브로커;이름=브로커" 형식)
+func InitKafkaClusters(spec string) error {
+	clusters := make(map[string][]string)
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, list, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return fmt.Errorf("invalid cluster %q (expected name=host:port,...)", entry)
+		}
+		if _, exists := clusters[name]; exists {
+			return fmt.Errorf("cluster %q is defined more than once", name)
+		}
+		var brokers []string
+		for _, broker := range strings.Split(list, ",") {
+			if broker = strings.TrimSpace(broker); broker != "" {
+				brokers = append(brokers, broker)
+			}
+		}
+		if len(brokers) == 0 {
+			return fmt.Errorf("cluster %q has no brokers", name)
+		}
+		clusters[name] = brokers
+	}
+	kafkaClusters = clusters
+	return nil
+}
+
+// clusterAddr 등록된 클러스터 이름의 브로커 주소 (비어 있으면 이 대시보드의 클러스터, 등록되지 않은 이름은 오류)
+func clusterAddr(name string) (net.Addr, error) {
+	if name == "" {
+		return kafka.TCP(kafkaBrokers), nil
+	}
+	brokers, ok := kafkaClusters[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown cluster %q (configure it in KAFKA_CLUSTERS)", name)
+	}
+	return kafka.TCP(brokers...), nil
+}
+
+// newKafkaClient 관리 API 요청용 클라이언트 생성
+func newKafkaClient() *kafka.Client {
+	return &kafka.Client{
+		Addr:    kafka.TCP(kafkaBrokers),
+		Timeout: 10 * time.Second,
+	}
+}
+
 // CreateTopicRequest 토픽 생성 요청
 type CreateTopicRequest struct {
-	Name              string `json:"name" binding:"required"`
-	Partitions        int    `json:"partitions" binding:"required,min=1"`
-	ReplicationFactor int    `json:"replicationFactor" binding:"required,min=1"`
+	Name              string            `json:"name" binding:"required"`
+	Partitions        int               `json:"partitions" binding:"required,min=1"`
+	ReplicationFactor int               `json:"replicationFactor" binding:"required,min=1"`
+	Configs           map[string]string `json:"configs"`
 }
 
 // TopicInfo 토픽 정보
@@ -89,6 +148,19 @@ func CreateTopic(c *gin.Context) {
 		return
 	}
 
+	violations := topicPolicy.checkTopic(req.Name, req.Partitions, req.ReplicationFactor)
+	configViolations, err := topicPolicy.checkConfigs(req.ReplicationFactor, req.Configs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to check topic policy: %v", err),
+		})
+		return
+	}
+	if violations = append(violations, configViolations...); len(violations) > 0 {
+		respondPolicyViolations(c, violations)
+		return
+	}
+
 	conn, err := kafka.Dial("tcp", kafkaBrokers)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -120,6 +192,7 @@ func CreateTopic(c *gin.Context) {
 			Topic:             req.Name,
 			NumPartitions:     req.Partitions,
 			ReplicationFactor: req.ReplicationFactor,
+			ConfigEntries:     toConfigEntries(req.Configs),
 		},
 	}
 
@@ -193,48 +266,105 @@ func GetTopicDetails(c *gin.Context) {
 }
 
 // DeleteTopic 토픽 삭제
+//
+// 보호 토픽은 거부하고, ?dryRun=true로 영향(Consumer Group, 최근 produce)을 확인해 받은
+// 확인 토큰을 ?confirm=으로 전달해야 삭제한다. ?soft=true면 보관 토픽으로 복사한 뒤 삭제한다.
 func DeleteTopic(c *gin.Context) {
 	topicName := c.Param("name")
 
-	conn, err := kafka.Dial("tcp", kafkaBrokers)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": fmt.Sprintf("Failed to connect to Kafka: %v", err),
+	if reason := protectedTopicReason(topicName); reason != "" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": fmt.Sprintf("Topic %s is protected (%s)", topicName, reason),
 		})
 		return
 	}
-	defer conn.Close()
 
-	controller, err := conn.Controller()
+	partitions, err := readPartitions(topicName)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": fmt.Sprintf("Failed to get controller: %v", err),
+			"error": fmt.Sprintf("Failed to read partitions: %v", err),
 		})
 		return
 	}
 
-	controllerConn, err := kafka.Dial("tcp", fmt.Sprintf("%s:%d", controller.Host, controller.Port))
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": fmt.Sprintf("Failed to connect to controller: %v", err),
+	if len(partitions) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Topic not found",
 		})
 		return
 	}
-	defer controllerConn.Close()
 
-	err = controllerConn.DeleteTopics(topicName)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": fmt.Sprintf("Failed to delete topic: %v", err),
+	if c.Query("dryRun") == "true" {
+		preview, err := previewTopicDelete(topicName, partitions)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": fmt.Sprintf("Failed to preview topic delete: %v", err),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status":  "validated",
+			"preview": preview,
+			"message": "Pass confirm_token as ?confirm= to delete the topic",
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	if !consumeDeleteToken(topicName, c.Query("confirm")) {
+		c.JSON(http.StatusPreconditionRequired, gin.H{
+			"error": "a valid confirmation token is required; call DELETE with ?dryRun=true first and pass its confirm_token as ?confirm=",
+		})
+		return
+	}
+
+	response := gin.H{
 		"status":  "success",
 		"topic":   topicName,
 		"message": "Topic deleted successfully",
-	})
+	}
+
+	if c.Query("soft") == "true" {
+		archive, copied, err := archiveTopic(c.Request.Context(), topicName, partitions)
+		var policyErr *policyViolationError
+		if errors.As(err, &policyErr) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":         fmt.Sprintf("Archive topic violates the topic policy, original kept: %s", policyErr.topic),
+				"archive_topic": policyErr.topic,
+				"violations":    policyErr.violations,
+			})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":           fmt.Sprintf("Failed to archive topic, original kept: %v", err),
+				"archive_topic":   archive,
+				"copied_messages": copied,
+			})
+			return
+		}
+		response["archive_topic"] = archive
+		response["copied_messages"] = copied
+		response["message"] = "Topic archived and deleted successfully"
+	}
+
+	client := newKafkaClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	resp, err := client.DeleteTopics(ctx, &kafka.DeleteTopicsRequest{Topics: []string{topicName}})
+	if err == nil {
+		err = resp.Errors[topicName]
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to delete topic: %v", err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
 // getPartitionOffsets 파티션의 첫 번째와 마지막 오프셋 조회
@@ -304,64 +434,460 @@ func GetBrokers(c *gin.Context) {
 		})
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"brokers": brokerInfos,
 		"count":   len(brokerInfos),
-	})
+	}
+
+	// 클라이언트 쿼터는 각 브로커에서 동일하게 적용됨
+	quotas, err := describeClientQuotas(nil)
+	if err != nil {
+		response["client_quotas_error"] = err.Error()
+	} else {
+		response["client_quotas"] = quotas
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
-// GetClusterInfo 클러스터 정보 조회
+// GetClusterInfo 클러스터 정보 조회 (클러스터 ID, 컨트롤러, KRaft/ZooKeeper 모드, 브로커별 API 버전)
 func GetClusterInfo(c *gin.Context) {
-	conn, err := kafka.Dial("tcp", kafkaBrokers)
+	client := newKafkaClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	metadata, err := client.Metadata(ctx, &kafka.MetadataRequest{})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": fmt.Sprintf("Failed to connect to Kafka: %v", err),
+			"error": fmt.Sprintf("Failed to get cluster metadata: %v", err),
 		})
 		return
 	}
-	defer conn.Close()
 
-	// 브로커 정보
-	brokers, err := conn.Brokers()
+	partitionCount := 0
+	for _, t := range metadata.Topics {
+		partitionCount += len(t.Partitions)
+	}
+
+	brokers := describeBrokerVersions(metadata.Brokers)
+
+	// 추정 버전이 둘 이상이면 혼합 버전 클러스터
+	versionSet := make(map[string]bool)
+	for _, b := range brokers {
+		if b.Error == "" {
+			versionSet[b.KafkaVersion] = true
+		}
+	}
+	versions := make([]string, 0, len(versionSet))
+	for v := range versionSet {
+		versions = append(versions, v)
+	}
+	sort.Strings(versions)
+
+	c.JSON(http.StatusOK, gin.H{
+		"cluster_id":      metadata.ClusterID,
+		"mode":            inferClusterMode(brokers),
+		"broker_count":    len(metadata.Brokers),
+		"topic_count":     len(metadata.Topics),
+		"partition_count": partitionCount,
+		"controller": gin.H{
+			"id":   metadata.Controller.ID,
+			"host": metadata.Controller.Host,
+			"port": metadata.Controller.Port,
+		},
+		"brokers":             brokers,
+		"kafka_versions":      versions,
+		"mixed_versions":      len(versions) > 1,
+		"api_versions_differ": apiVersionsDiffer(brokers),
+	})
+}
+
+// ACLRule ACL 생성 요청 항목 (resourceType: topic, group, cluster, transactionalid)
+type ACLRule struct {
+	ResourceType kafka.ResourceType      `json:"resourceType"`
+	ResourceName string                  `json:"resourceName" binding:"required"`
+	PatternType  kafka.PatternType       `json:"patternType"` // literal(기본값), prefixed
+	Principal    string                  `json:"principal" binding:"required"`
+	Host         string                  `json:"host"` // 기본값 *
+	Operation    kafka.ACLOperationType  `json:"operation"`
+	Permission   kafka.ACLPermissionType `json:"permission"`
+}
+
+// CreateACLsRequest ACL 생성 요청
+type CreateACLsRequest struct {
+	ACLs []ACLRule `json:"acls" binding:"required,min=1,dive"`
+}
+
+// ACLBinding ACL 조회 결과 항목
+type ACLBinding struct {
+	ResourceType kafka.ResourceType      `json:"resource_type"`
+	ResourceName string                  `json:"resource_name"`
+	PatternType  kafka.PatternType       `json:"pattern_type"`
+	Principal    string                  `json:"principal"`
+	Host         string                  `json:"host"`
+	Operation    kafka.ACLOperationType  `json:"operation"`
+	Permission   kafka.ACLPermissionType `json:"permission"`
+}
+
+// topicOperations 토픽 리소스에 적용되는 ACL 연산
+var topicOperations = []kafka.ACLOperationType{
+	kafka.ACLOperationTypeRead,
+	kafka.ACLOperationTypeWrite,
+	kafka.ACLOperationTypeCreate,
+	kafka.ACLOperationTypeDelete,
+	kafka.ACLOperationTypeAlter,
+	kafka.ACLOperationTypeDescribe,
+	kafka.ACLOperationTypeDescribeConfigs,
+	kafka.ACLOperationTypeAlterConfigs,
+}
+
+// ListACLs ACL 목록 조회 (resourceType, resourceName, patternType, principal, host, operation, permission 필터)
+func ListACLs(c *gin.Context) {
+	filter, err := parseACLFilter(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": fmt.Sprintf("Failed to get brokers: %v", err),
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	acls, err := describeACLs(filter)
+	if err != nil {
+		c.JSON(aclErrorStatus(err), gin.H{
+			"error": fmt.Sprintf("Failed to describe ACLs: %v", err),
 		})
 		return
 	}
 
-	// 컨트롤러 정보
-	controller, err := conn.Controller()
+	c.JSON(http.StatusOK, gin.H{
+		"acls":  acls,
+		"count": len(acls),
+	})
+}
+
+// CreateACLs ACL 생성
+func CreateACLs(c *gin.Context) {
+	var req CreateACLsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entries := make([]kafka.ACLEntry, 0, len(req.ACLs))
+	for i, rule := range req.ACLs {
+		rule, err := normalizeACLRule(rule)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("acls[%d]: %v", i, err),
+			})
+			return
+		}
+
+		entries = append(entries, kafka.ACLEntry{
+			ResourceType:        rule.ResourceType,
+			ResourceName:        rule.ResourceName,
+			ResourcePatternType: rule.PatternType,
+			Principal:           rule.Principal,
+			Host:                rule.Host,
+			Operation:           rule.Operation,
+			PermissionType:      rule.Permission,
+		})
+	}
+
+	client := newKafkaClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := client.CreateACLs(ctx, &kafka.CreateACLsRequest{ACLs: entries})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": fmt.Sprintf("Failed to get controller: %v", err),
+			"error": fmt.Sprintf("Failed to create ACLs: %v", err),
 		})
 		return
 	}
 
-	// 토픽 수
-	partitions, err := conn.ReadPartitions()
+	results := make([]gin.H, 0, len(resp.Errors))
+	failed := 0
+	for i, e := range resp.Errors {
+		result := gin.H{"index": i, "status": "created"}
+		if e != nil {
+			result["status"] = "failed"
+			result["error"] = e.Error()
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	code, status := http.StatusOK, "success"
+	if failed > 0 {
+		status = "partial"
+		if failed == len(results) {
+			code, status = aclErrorStatus(resp.Errors[0]), "failed"
+		}
+	}
+
+	c.JSON(code, gin.H{
+		"status":  status,
+		"results": results,
+		"failed":  failed,
+		"message": "ACLs created",
+	})
+}
+
+// DeleteACLs 필터와 일치하는 ACL 삭제 (모든 필터가 비어 있으면 all=true 필요)
+func DeleteACLs(c *gin.Context) {
+	filter, err := parseACLFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if isWildcardACLFilter(filter) && c.Query("all") != "true" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "refusing to delete every ACL; narrow the filter or pass all=true",
+		})
+		return
+	}
+
+	client := newKafkaClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := client.DeleteACLs(ctx, &kafka.DeleteACLsRequest{
+		Filters: []kafka.DeleteACLsFilter{
+			{
+				ResourceTypeFilter:        filter.ResourceTypeFilter,
+				ResourceNameFilter:        filter.ResourceNameFilter,
+				ResourcePatternTypeFilter: filter.ResourcePatternTypeFilter,
+				PrincipalFilter:           filter.PrincipalFilter,
+				HostFilter:                filter.HostFilter,
+				Operation:                 filter.Operation,
+				PermissionType:            filter.PermissionType,
+			},
+		},
+	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": fmt.Sprintf("Failed to read partitions: %v", err),
+			"error": fmt.Sprintf("Failed to delete ACLs: %v", err),
 		})
 		return
 	}
 
-	topicMap := make(map[string]bool)
-	for _, p := range partitions {
-		topicMap[p.Topic] = true
+	deleted := []ACLBinding{}
+	for _, r := range resp.Results {
+		if r.Error != nil {
+			c.JSON(aclErrorStatus(r.Error), gin.H{
+				"error": fmt.Sprintf("Failed to delete ACLs: %v", r.Error),
+			})
+			return
+		}
+		for _, m := range r.MatchingACLs {
+			if m.Error != nil {
+				continue
+			}
+			deleted = append(deleted, ACLBinding{
+				ResourceType: m.ResourceType,
+				ResourceName: m.ResourceName,
+				PatternType:  m.ResourcePatternType,
+				Principal:    m.Principal,
+				Host:         m.Host,
+				Operation:    m.Operation,
+				Permission:   m.PermissionType,
+			})
+		}
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"cluster_id":      fmt.Sprintf("kafka-cluster-%d", time.Now().Unix()),
-		"broker_count":    len(brokers),
-		"topic_count":     len(topicMap),
-		"partition_count": len(partitions),
-		"controller": gin.H{
-			"id":   controller.ID,
-			"host": controller.Host,
-			"port": controller.Port,
-		},
+		"status":  "success",
+		"deleted": deleted,
+		"count":   len(deleted),
+		"message": "ACLs deleted",
+	})
+}
+
+// CheckTopicAccess principal이 토픽에 대해 수행할 수 있는 연산 평가 (?principal=User:alice&topic=orders&host=)
+func CheckTopicAccess(c *gin.Context) {
+	principal := c.Query("principal")
+	topic := c.Query("topic")
+	host := c.Query("host")
+
+	if principal == "" || topic == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "principal and topic are required",
+		})
+		return
+	}
+
+	// MATCH 패턴은 리터럴, 와일드카드(*), 접두사 ACL 중 토픽 이름에 적용되는 것을 모두 반환
+	acls, err := describeACLs(kafka.ACLFilter{
+		ResourceTypeFilter:        kafka.ResourceTypeTopic,
+		ResourceNameFilter:        topic,
+		ResourcePatternTypeFilter: kafka.PatternTypeMatch,
+		Operation:                 kafka.ACLOperationTypeAny,
+		PermissionType:            kafka.ACLPermissionTypeAny,
 	})
+	if err != nil {
+		c.JSON(aclErrorStatus(err), gin.H{
+			"error": fmt.Sprintf("Failed to describe ACLs: %v", err),
+		})
+		return
+	}
+
+	var applicable []ACLBinding
+	for _, acl := range acls {
+		if acl.Principal != principal && acl.Principal != "User:*" {
+			continue
+		}
+		if host != "" && acl.Host != "*" && acl.Host != host {
+			continue
+		}
+		applicable = append(applicable, acl)
+	}
+
+	operations := make([]gin.H, 0, len(topicOperations))
+	for _, op := range topicOperations {
+		allowed, reason := evaluateACLs(applicable, op)
+		operations = append(operations, gin.H{
+			"operation": op,
+			"allowed":   allowed,
+			"reason":    reason,
+		})
+	}
+
+	if applicable == nil {
+		applicable = []ACLBinding{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"principal":      principal,
+		"topic":          topic,
+		"host":           host,
+		"operations":     operations,
+		"matching_acls":  applicable,
+		"topic_has_acls": len(acls) > 0,
+		"note":           "If the topic has no ACLs at all, access depends on the broker's allow.everyone.if.no.acl.found setting",
+	})
+}
+
+// evaluateACLs Kafka 인가 규칙(DENY 우선, All 포함, Describe 계열 암묵 허용)으로 연산 허용 여부 판단
+func evaluateACLs(acls []ACLBinding, op kafka.ACLOperationType) (bool, string) {
+	// Read/Write/Delete/Alter 허용 시 Describe 허용, AlterConfigs 허용 시 DescribeConfigs 허용
+	implied := []kafka.ACLOperationType{op, kafka.ACLOperationTypeAll}
+	switch op {
+	case kafka.ACLOperationTypeDescribe:
+		implied = append(implied, kafka.ACLOperationTypeRead, kafka.ACLOperationTypeWrite,
+			kafka.ACLOperationTypeDelete, kafka.ACLOperationTypeAlter)
+	case kafka.ACLOperationTypeDescribeConfigs:
+		implied = append(implied, kafka.ACLOperationTypeAlterConfigs)
+	}
+
+	for _, acl := range acls {
+		if acl.Permission == kafka.ACLPermissionTypeDeny && (acl.Operation == op || acl.Operation == kafka.ACLOperationTypeAll) {
+			return false, fmt.Sprintf("denied by %s ACL on %s %q", acl.Operation, acl.PatternType, acl.ResourceName)
+		}
+	}
+
+	for _, acl := range acls {
+		if acl.Permission != kafka.ACLPermissionTypeAllow {
+			continue
+		}
+		for _, candidate := range implied {
+			if acl.Operation == candidate {
+				return true, fmt.Sprintf("allowed by %s ACL on %s %q", acl.Operation, acl.PatternType, acl.ResourceName)
+			}
+		}
+	}
+
+	return false, "no matching allow ACL"
+}
+
+// describeACLs 필터와 일치하는 ACL을 평탄화된 목록으로 조회
+func describeACLs(filter kafka.ACLFilter) ([]ACLBinding, error) {
+	client := newKafkaClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := client.DescribeACLs(ctx, &kafka.DescribeACLsRequest{Filter: filter})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+
+	acls := []ACLBinding{}
+	for _, r := range resp.Resources {
+		for _, a := range r.ACLs {
+			acls = append(acls, ACLBinding{
+				ResourceType: r.ResourceType,
+				ResourceName: r.ResourceName,
+				PatternType:  r.PatternType,
+				Principal:    a.Principal,
+				Host:         a.Host,
+				Operation:    a.Operation,
+				Permission:   a.PermissionType,
+			})
+		}
+	}
+
+	return acls, nil
+}
+
+// parseACLFilter 쿼리 파라미터로 ACL 필터 생성 (지정하지 않은 항목은 any)
+func parseACLFilter(c *gin.Context) (kafka.ACLFilter, error) {
+	filter := kafka.ACLFilter{
+		ResourceTypeFilter:        kafka.ResourceTypeAny,
+		ResourceNameFilter:        c.Query("resourceName"),
+		ResourcePatternTypeFilter: kafka.PatternTypeAny,
+		PrincipalFilter:           c.Query("principal"),
+		HostFilter:                c.Query("host"),
+		Operation:                 kafka.ACLOperationTypeAny,
+		PermissionType:            kafka.ACLPermissionTypeAny,
+	}
+
+	if v := c.Query("resourceType"); v != "" {
+		if err := filter.ResourceTypeFilter.UnmarshalText([]byte(v)); err != nil {
+			return filter, err
+		}
+	}
+	if v := c.Query("patternType"); v != "" {
+		if err := filter.ResourcePatternTypeFilter.UnmarshalText([]byte(v)); err != nil {
+			return filter, err
+		}
+	}
+	if v := c.Query("operation"); v != "" {
+		if err := filter.Operation.UnmarshalText([]byte(v)); err != nil {
+			return filter, err
+		}
+	}
+	if v := c.Query("permission"); v != "" {
+		if err := filter.PermissionType.UnmarshalText([]byte(v)); err != nil {
+			return filter, err
+		}
+	}
+
+	return filter, nil
+}
+
+// isWildcardACLFilter 모든 ACL과 일치하는 필터인지 확인
+func isWildcardACLFilter(filter kafka.ACLFilter) bool {
+	return filter.ResourceTypeFilter == kafka.ResourceTypeAny &&
+		filter.ResourceNameFilter == "" &&
+		filter.PrincipalFilter == "" &&
+		filter.HostFilter == "" &&
+		filter.Operation == kafka.ACLOperationTypeAny &&
+		filter.PermissionType == kafka.ACLPermissionTypeAny
+}
+
+// aclErrorStatus ACL 오류에 맞는 HTTP 상태 코드 (authorizer 미설정 시 501)
+func aclErrorStatus(err error) int {
+	if errors.Is(err, kafka.SecurityDisabled) {
+		return http.StatusNotImplemented
+	}
+	if errors.Is(err, kafka.ClusterAuthorizationFailed) {
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
 }
@@ -0,0 +1,531 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"backend/auth"
+
+	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	// auditMaxBodyBytes 파라미터로 기록할 요청 본문 최대 크기 (초과 시 크기만 기록)
+	auditMaxBodyBytes = 1 << 20
+	// auditMaxStringLen 기록할 문자열 파라미터 최대 길이
+	auditMaxStringLen = 256
+	// auditMaxArrayLen 기록할 배열 파라미터 최대 길이 (초과 시 항목 수만 기록)
+	auditMaxArrayLen = 20
+	// auditMaxResponseBytes 오류 메시지 추출을 위해 보관할 응답 본문 최대 크기
+	auditMaxResponseBytes = 4096
+	// auditClusterRetry 클러스터 ID 조회 실패 후 재시도 간격
+	auditClusterRetry = time.Minute
+)
+
+// AuditRecord 변경 작업 감사 기록
+type AuditRecord struct {
+	Time       time.Time              `json:"time"`
+	Actor      string                 `json:"actor"`
+	AuthMethod string                 `json:"auth_method,omitempty"`
+	RemoteAddr string                 `json:"remote_addr,omitempty"`
+	Cluster    string                 `json:"cluster"`
+	Action     string                 `json:"action"`
+	Target     string                 `json:"target,omitempty"`
+	Params     map[string]interface{} `json:"params,omitempty"`
+	Status     int                    `json:"status,omitempty"`
+	Result     string                 `json:"result"` // success, partial, failure
+	Error      string                 `json:"error,omitempty"`
+	DurationMs int64                  `json:"duration_ms"`
+}
+
+// auditRedacted 가린 설정 값 대신 기록하는 값
+const auditRedacted = "[redacted]"
+
+// auditPayloadActions 요청 본문에 메시지 내용이 담기는 작업 (키/값/헤더는 크기만 기록)
+var auditPayloadActions = map[string]bool{
+	"message.produce":             true,
+	"message.produce_batch":       true,
+	"message.produce_transaction": true,
+	"loadgen.start":               true,
+	"dlq.redrive":                 true,
+}
+
+// auditPayloadFields 메시지 내용 필드 (소문자, 생성기 템플릿 포함)
+var auditPayloadFields = map[string]bool{
+	"key":           true,
+	"value":         true,
+	"headers":       true,
+	"keytemplate":   true,
+	"valuetemplate": true,
+}
+
+// auditSensitiveConfigWords 이름에 포함되면 값을 기록하지 않는 설정 (Kafka가 PASSWORD 타입으로 다루는 설정 포함)
+var auditSensitiveConfigWords = []string{"password", "secret", "jaas", "credential", "token", "certificate", ".key"}
+
+// auditLog 감사 기록 저장소 (추가 전용 파일 + 선택적 Kafka 토픽)
+var auditLog struct {
+	mu     sync.Mutex
+	path   string
+	file   *os.File
+	writer *kafka.Writer
+
+	cluster      string
+	clusterTried time.Time
+}
+
+// InitAuditLog 감사 기록 파일을 열고, topic이 지정되면 Kafka 토픽에도 기록
+func InitAuditLog(path, topic string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0640)
+	if err != nil {
+		return err
+	}
+
+	auditLog.mu.Lock()
+	defer auditLog.mu.Unlock()
+
+	auditLog.path = path
+	auditLog.file = f
+	if topic != "" {
+		auditLog.writer = &kafka.Writer{
+			Addr:         kafka.TCP(kafkaBrokers),
+			Topic:        topic,
+			Balancer:     &kafka.Hash{}, // 대상별 순서 보장
+			RequiredAcks: kafka.RequireAll,
+			Async:        true,
+			ErrorLogger: kafka.LoggerFunc(func(format string, args ...interface{}) {
+				log.Printf("audit topic: "+format, args...)
+			}),
+		}
+	}
+	return nil
+}
+
+// CloseAuditLog 대기 중인 Kafka 기록을 전송하고 파일을 닫음
+func CloseAuditLog() {
+	auditLog.mu.Lock()
+	defer auditLog.mu.Unlock()
+
+	if auditLog.writer != nil {
+		auditLog.writer.Close()
+		auditLog.writer = nil
+	}
+	if auditLog.file != nil {
+		auditLog.file.Close()
+		auditLog.file = nil
+	}
+}
+
+// RecordAudit 감사 기록 저장 (파일 기록 실패는 로그로만 남김)
+func RecordAudit(record AuditRecord) {
+	if record.Time.IsZero() {
+		record.Time = time.Now().UTC()
+	}
+	if record.Cluster == "" {
+		record.Cluster = auditClusterID()
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		log.Printf("audit: %v", err)
+		return
+	}
+
+	auditLog.mu.Lock()
+	defer auditLog.mu.Unlock()
+
+	if auditLog.file != nil {
+		if _, err := auditLog.file.Write(append(line, '\n')); err != nil {
+			log.Printf("audit: write %s: %v", auditLog.path, err)
+		}
+	}
+	if auditLog.writer != nil {
+		msg := kafka.Message{Key: []byte(record.Target), Value: line, Time: record.Time}
+		if err := auditLog.writer.WriteMessages(context.Background(), msg); err != nil {
+			log.Printf("audit: write topic: %v", err)
+		}
+	}
+}
+
+// Audit 요청 결과를 감사 기록으로 남기는 미들웨어 (권한 검사보다 앞에 두어 거부된 시도도 기록)
+func Audit(action string, target ...auth.TopicSource) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		params := auditParams(c, action)
+
+		// 대상 추출이 요청을 거부하는 경우(모호한 본문 등)에도 응답 오류를 기록하도록 먼저 교체
+		recorder := &auditResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		var name string
+		for _, source := range target {
+			if name = source(c); name != "" || c.IsAborted() {
+				break
+			}
+		}
+
+		c.Next()
+
+		record := AuditRecord{
+			Time:       start.UTC(),
+			Actor:      "unknown",
+			RemoteAddr: c.ClientIP(),
+			Action:     action,
+			Target:     name,
+			Params:     params,
+			Status:     recorder.Status(),
+			DurationMs: time.Since(start).Milliseconds(),
+		}
+		if principal := auth.PrincipalFrom(c); principal != nil {
+			record.Actor = principal.Name
+			record.AuthMethod = principal.Method
+		}
+
+		switch {
+		case record.Status >= http.StatusBadRequest:
+			record.Result = "failure"
+			record.Error = responseError(record.Status, recorder.body.Bytes())
+		case record.Status == http.StatusMultiStatus:
+			record.Result = "partial"
+		default:
+			record.Result = "success"
+		}
+
+		RecordAudit(record)
+	}
+}
+
+// GetAuditLog 감사 기록 조회 (actor, action, target, result, cluster, since, until, limit 필터, 최신순)
+func GetAuditLog(c *gin.Context) {
+	limit := 100
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 1000 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 1000"})
+			return
+		}
+		limit = n
+	}
+
+	var since, until time.Time
+	for _, p := range []struct {
+		name string
+		dst  *time.Time
+	}{{"since", &since}, {"until", &until}} {
+		v := c.Query(p.name)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid %s (expected RFC3339): %v", p.name, err)})
+			return
+		}
+		*p.dst = t
+	}
+
+	filters := map[string]string{
+		"actor":   c.Query("actor"),
+		"action":  c.Query("action"),
+		"target":  c.Query("target"),
+		"result":  c.Query("result"),
+		"cluster": c.Query("cluster"),
+	}
+
+	auditLog.mu.Lock()
+	path := auditLog.path
+	auditLog.mu.Unlock()
+	if path == "" {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Audit log is not enabled"})
+		return
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to read audit log: %v", err)})
+		return
+	}
+	defer f.Close()
+
+	// 조건에 맞는 마지막 limit개만 유지
+	matched := make([]AuditRecord, 0, limit)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 4*auditMaxBodyBytes)
+	for scanner.Scan() {
+		var record AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+		if !since.IsZero() && record.Time.Before(since) || !until.IsZero() && record.Time.After(until) {
+			continue
+		}
+		if !auditMatches(record, filters) {
+			continue
+		}
+		if len(matched) == limit {
+			matched = matched[1:]
+		}
+		matched = append(matched, record)
+	}
+	if err := scanner.Err(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to read audit log: %v", err)})
+		return
+	}
+
+	records := make([]AuditRecord, len(matched))
+	for i, record := range matched {
+		records[len(matched)-1-i] = record
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"records": records,
+		"count":   len(records),
+	})
+}
+
+// auditMatches 필터 일치 여부 (action은 접두사 일치, 예: topic. → topic.create, topic.delete)
+func auditMatches(record AuditRecord, filters map[string]string) bool {
+	if v := filters["action"]; v != "" && !strings.HasPrefix(record.Action, v) {
+		return false
+	}
+	fields := map[string]string{
+		"actor":   record.Actor,
+		"target":  record.Target,
+		"result":  record.Result,
+		"cluster": record.Cluster,
+	}
+	for name, value := range fields {
+		if v := filters[name]; v != "" && v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// auditParams 경로/쿼리 파라미터와 JSON 본문을 기록용으로 요약 (본문은 핸들러를 위해 복원)
+//
+// 민감한 설정 값은 이름만 남기고, 메시지를 전송하는 작업의 키/값/헤더는 내용 대신 크기만 기록한다.
+func auditParams(c *gin.Context, action string) map[string]interface{} {
+	params := make(map[string]interface{})
+	for _, p := range c.Params {
+		params[p.Key] = p.Value
+	}
+	for key, values := range c.Request.URL.Query() {
+		if key == "access_token" {
+			continue
+		}
+		params[key] = strings.Join(values, ",")
+	}
+
+	if c.Request.Body == nil || !strings.HasPrefix(c.ContentType(), "application/json") {
+		return params
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, auditMaxBodyBytes+1))
+	c.Request.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), c.Request.Body), c.Request.Body}
+	if err != nil || len(body) == 0 {
+		return params
+	}
+	if len(body) > auditMaxBodyBytes {
+		params["body"] = fmt.Sprintf("[more than %d bytes]", auditMaxBodyBytes)
+		return params
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return params
+	}
+	params["body"] = summarizeAuditValue(redactAuditValue(decoded, auditPayloadActions[action]))
+	return params
+}
+
+// redactAuditValue 설정 맵(configs, values)과 설정 변경 목록의 민감한 값을 가리고, payload면 메시지 내용을 크기로 대체
+func redactAuditValue(v interface{}, payload bool) interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		for i := range v {
+			v[i] = redactAuditValue(v[i], payload)
+		}
+	case map[string]interface{}:
+		// 설정 변경 항목 {"name": ..., "value": ...} (바인딩과 같이 필드 이름은 대소문자 구분 없음)
+		sensitive := false
+		for key, value := range v {
+			if name, ok := value.(string); ok && strings.EqualFold(key, "name") && sensitiveConfigName(name) {
+				sensitive = true
+			}
+		}
+		for key, value := range v {
+			field := strings.ToLower(key)
+			switch {
+			case payload && auditPayloadFields[field]:
+				v[key] = auditPayloadSize(value)
+			case sensitive && field == "value":
+				v[key] = auditRedacted
+			case field == "configs" || field == "values":
+				if configs, ok := value.(map[string]interface{}); ok {
+					for name := range configs {
+						if sensitiveConfigName(name) {
+							configs[name] = auditRedacted
+						}
+					}
+				}
+				v[key] = redactAuditValue(value, payload)
+			default:
+				v[key] = redactAuditValue(value, payload)
+			}
+		}
+	}
+	return v
+}
+
+// auditPayloadSize 메시지 키/값/헤더 대신 기록할 크기 (null은 그대로)
+func auditPayloadSize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		return fmt.Sprintf("[%d bytes]", len(v))
+	case []interface{}:
+		size := 0
+		for _, item := range v {
+			if h, ok := item.(map[string]interface{}); ok {
+				for _, field := range h {
+					if s, ok := field.(string); ok {
+						size += len(s)
+					}
+				}
+			}
+		}
+		return fmt.Sprintf("[%d headers, %d bytes]", len(v), size)
+	}
+	return v
+}
+
+// sensitiveConfigName 값을 기록하지 않을 설정 이름 여부 (비밀번호, JAAS, 키/인증서 등)
+func sensitiveConfigName(name string) bool {
+	name = strings.ToLower(name)
+	for _, word := range auditSensitiveConfigWords {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// summarizeAuditValue 긴 문자열과 배열을 줄여 기록 크기 제한
+func summarizeAuditValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		if len(v) > auditMaxStringLen {
+			return fmt.Sprintf("%s...(%d bytes)", v[:auditMaxStringLen], len(v))
+		}
+		return v
+	case []interface{}:
+		if len(v) > auditMaxArrayLen {
+			return fmt.Sprintf("[%d items]", len(v))
+		}
+		for i := range v {
+			v[i] = summarizeAuditValue(v[i])
+		}
+		return v
+	case map[string]interface{}:
+		for key := range v {
+			v[key] = summarizeAuditValue(v[key])
+		}
+		return v
+	}
+	return v
+}
+
+// responseError 오류 응답 본문의 error 필드 (없으면 상태 코드 설명)
+func responseError(status int, body []byte) string {
+	var resp struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil || resp.Error == "" {
+		return http.StatusText(status)
+	}
+	return resp.Error
+}
+
+// auditClusterID 기록에 남길 클러스터 ID (조회 실패 시 브로커 주소, 1분 후 재시도)
+func auditClusterID() string {
+	auditLog.mu.Lock()
+	cluster, tried := auditLog.cluster, auditLog.clusterTried
+	auditLog.mu.Unlock()
+
+	if cluster != "" || time.Since(tried) < auditClusterRetry {
+		if cluster == "" {
+			return kafkaBrokers
+		}
+		return cluster
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	meta, err := newKafkaClient().Metadata(ctx, &kafka.MetadataRequest{Topics: []string{}})
+
+	auditLog.mu.Lock()
+	defer auditLog.mu.Unlock()
+	auditLog.clusterTried = time.Now()
+	if err != nil || meta.ClusterID == "" {
+		return kafkaBrokers
+	}
+	auditLog.cluster = meta.ClusterID
+	return auditLog.cluster
+}
+
+// BrokerTarget 경로의 브로커 ID를 감사 대상으로 기록 (broker:<id>, 토픽 이름과 구분)
+func BrokerTarget(param string) auth.TopicSource {
+	return func(c *gin.Context) string {
+		if id := c.Param(param); id != "" {
+			return "broker:" + id
+		}
+		return ""
+	}
+}
+
+// ClusterTarget 클러스터 단위 작업의 감사 대상 (cluster:<클러스터 ID>)
+func ClusterTarget() auth.TopicSource {
+	return func(c *gin.Context) string {
+		return "cluster:" + auditClusterID()
+	}
+}
+
+// auditResponseWriter 오류 메시지 추출을 위해 응답 본문 앞부분을 보관하는 ResponseWriter
+type auditResponseWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *auditResponseWriter) Write(data []byte) (int, error) {
+	if remaining := auditMaxResponseBytes - w.body.Len(); remaining > 0 {
+		if len(data) < remaining {
+			remaining = len(data)
+		}
+		w.body.Write(data[:remaining])
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *auditResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Unwrap http.ResponseController가 원래 ResponseWriter 기능(전이중 등)을 쓸 수 있도록 노출
+func (w *auditResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,688 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/protocol"
+)
+
+const (
+	// backupVersion 백업 파일 형식 버전
+	backupVersion = 1
+	// backupMagic 바이너리 백업 시작 표시 (gzip 압축을 푼 내용의 처음 4바이트)
+	backupMagic = "KBAK"
+	// backupTagRecord, backupTagEnd 바이너리 백업 항목 종류
+	backupTagRecord = 'R'
+	backupTagEnd    = 'E'
+	// backupNullLength 바이너리 백업에서 null 키/값/헤더 값의 길이
+	backupNullLength = 0xFFFFFFFF
+)
+
+// BackupManifest 백업 파일 첫 항목 (원본 토픽과 범위)
+type BackupManifest struct {
+	Version int    `json:"version"`
+	Format  string `json:"format"` // ndjson, binary
+	Topic   string `json:"topic"`
+	// PartitionCount 백업 시점 원본 토픽의 파티션 수 (복원 대상 토픽 생성 참고용)
+	PartitionCount int               `json:"partition_count"`
+	Partitions     []BackupPartition `json:"partitions"`
+	StartTime      *time.Time        `json:"start_time,omitempty"`
+	EndTime        *time.Time        `json:"end_time,omitempty"`
+	Isolation      string            `json:"isolation"`
+	CreatedAt      time.Time         `json:"created_at"`
+}
+
+// BackupPartition 백업한 파티션의 오프셋 구간 (end_offset 미포함)
+type BackupPartition struct {
+	Partition   int   `json:"partition"`
+	StartOffset int64 `json:"start_offset"`
+	EndOffset   int64 `json:"end_offset"`
+}
+
+// BackupRecord NDJSON 백업의 레코드 (UTF-8이 아닌 키/값/헤더 값은 base64, null은 JSON null)
+type BackupRecord struct {
+	Partition     int                  `json:"partition"`
+	Offset        int64                `json:"offset"`
+	Timestamp     time.Time            `json:"timestamp"`
+	Key           *string              `json:"key"`
+	KeyEncoding   string               `json:"key_encoding,omitempty"`
+	Value         *string              `json:"value"`
+	ValueEncoding string               `json:"value_encoding,omitempty"`
+	Headers       []BackupRecordHeader `json:"headers,omitempty"`
+}
+
+// BackupRecordHeader NDJSON 백업의 레코드 헤더
+type BackupRecordHeader struct {
+	Key      string  `json:"key"`
+	Value    *string `json:"value"`
+	Encoding string  `json:"encoding,omitempty"`
+}
+
+// BackupEnd 백업 파일 마지막 항목 (없으면 중간에 끊긴 파일)
+type BackupEnd struct {
+	Records int64 `json:"records"`
+}
+
+// backupLine NDJSON 백업의 한 줄 (첫 줄은 backup, 마지막 줄은 end, 나머지는 레코드)
+type backupLine struct {
+	Backup *BackupManifest `json:"backup,omitempty"`
+	End    *BackupEnd      `json:"end,omitempty"`
+	*BackupRecord
+}
+
+// BackupTopic 토픽(또는 범위)을 gzip으로 압축한 백업 파일로 내려받기
+//
+// 옵션은 쿼리 파라미터로 받는다 (format=ndjson|binary, partitions, startOffset, endOffset,
+// startTime, endTime, isolation). 범위는 요청 시점에 파티션별로 고정하고 파티션 순서대로
+// 기록한다. 응답을 보내기 시작한 뒤 오류가 나면 끝 표시 없이 응답을 끝낸다. 복원은
+// 스트리밍으로 전송하므로 그런 파일은 끊긴 지점까지 전송된 뒤 400으로 끝나며, 오류에
+// 이미 전송한 메시지 수와 파티션별 오프셋 범위가 담긴다.
+func BackupTopic(c *gin.Context) {
+	topic := c.Param("name")
+	query := c.Request.URL.Query()
+
+	format := query.Get("format")
+	switch format {
+	case "":
+		format = "ndjson"
+	case "ndjson", "binary":
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown format %q (expected ndjson or binary)", format)})
+		return
+	}
+
+	isolation := kafka.ReadCommitted
+	if v := query.Get("isolation"); v != "" {
+		var err error
+		if isolation, err = parseIsolation(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	bounds, err := parseOffsetBounds(query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	all, err := topicPartitionIDs(ctx, kafka.TCP(kafkaBrokers), topic)
+	if err != nil {
+		if errors.Is(err, kafka.UnknownTopicOrPartition) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Topic %s not found", topic)})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to get metadata: %v", err)})
+		return
+	}
+	partitions := all
+	if v := query.Get("partitions"); v != "" {
+		if partitions, err = parsePartitionList(v, all); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	ranges, err := resolveOffsetRanges(ctx, kafka.TCP(kafkaBrokers), topic, partitions, isolation, bounds)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to resolve offset range: %v", err)})
+		return
+	}
+	cancel()
+
+	manifest := BackupManifest{
+		Version:        backupVersion,
+		Format:         format,
+		Topic:          topic,
+		PartitionCount: len(all),
+		Partitions:     make([]BackupPartition, len(ranges)),
+		StartTime:      bounds.StartTime,
+		EndTime:        bounds.EndTime,
+		Isolation:      isolationName(isolation),
+		CreatedAt:      time.Now().UTC(),
+	}
+	for i, r := range ranges {
+		manifest.Partitions[i] = BackupPartition{Partition: r.partition, StartOffset: r.start, EndOffset: r.end}
+	}
+
+	ext := ".ndjson.gz"
+	if format == "binary" {
+		ext = ".kbak"
+	}
+	filename := topic + "-" + manifest.CreatedAt.Format("20060102T150405Z") + ext
+	c.Header("Content-Type", "application/gzip")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	zw := gzip.NewWriter(c.Writer)
+	w := newBackupWriter(format, zw)
+	records, err := writeBackup(c.Request.Context(), w, manifest, ranges, isolation)
+	if err == nil {
+		err = w.writeEnd(records)
+	}
+	if closeErr := zw.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		log.Printf("backup %s: stopped after %d records: %v", topic, records, err)
+		c.Error(err)
+	}
+}
+
+// writeBackup 파티션 구간의 레코드를 차례로 기록하고 기록한 레코드 수 반환
+func writeBackup(ctx context.Context, w backupWriter, manifest BackupManifest, ranges []offsetRange, isolation kafka.IsolationLevel) (int64, error) {
+	if err := w.writeManifest(manifest); err != nil {
+		return 0, err
+	}
+
+	var records int64
+	for _, r := range ranges {
+		for offset := r.start; offset < r.end; {
+			fetched, err := fetchRecords(ctx, kafka.TCP(kafkaBrokers), manifest.Topic, r.partition, offset, isolation, func(rec *protocol.Record) (bool, error) {
+				if rec.Offset >= r.end {
+					return false, nil
+				}
+				msg := kafka.Message{Partition: r.partition, Offset: rec.Offset, Time: rec.Time, Headers: rec.Headers}
+				var err error
+				if msg.Key, err = recordBytes(rec.Key); err != nil {
+					return false, err
+				}
+				if msg.Value, err = recordBytes(rec.Value); err != nil {
+					return false, err
+				}
+				if err := w.writeRecord(msg); err != nil {
+					return false, err
+				}
+				records++
+				return true, nil
+			})
+			if err != nil {
+				return records, fmt.Errorf("partition %d offset %d: %w", r.partition, offset, err)
+			}
+			if fetched.next == offset {
+				break // 더 읽을 레코드 없음
+			}
+			offset = fetched.next
+		}
+	}
+	return records, nil
+}
+
+// parseOffsetBounds 쿼리의 startOffset, endOffset(포함), startTime, endTime(미포함, RFC3339)
+func parseOffsetBounds(query url.Values) (offsetBounds, error) {
+	var bounds offsetBounds
+	for _, p := range []struct {
+		name string
+		dst  **int64
+	}{{"startOffset", &bounds.StartOffset}, {"endOffset", &bounds.EndOffset}} {
+		v := query.Get(p.name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			return bounds, fmt.Errorf("%s must be a non-negative integer", p.name)
+		}
+		*p.dst = &n
+	}
+	for _, p := range []struct {
+		name string
+		dst  **time.Time
+	}{{"startTime", &bounds.StartTime}, {"endTime", &bounds.EndTime}} {
+		v := query.Get(p.name)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return bounds, fmt.Errorf("invalid %s (expected RFC3339): %v", p.name, err)
+		}
+		*p.dst = &t
+	}
+
+	if bounds.StartOffset != nil && bounds.EndOffset != nil && *bounds.EndOffset < *bounds.StartOffset {
+		return bounds, errors.New("endOffset must not be less than startOffset")
+	}
+	if bounds.StartTime != nil && bounds.EndTime != nil && !bounds.EndTime.After(*bounds.StartTime) {
+		return bounds, errors.New("endTime must be after startTime")
+	}
+	return bounds, nil
+}
+
+// parsePartitionList 쉼표로 구분한 파티션 번호 (토픽에 있어야 하고 중복 불가)
+func parsePartitionList(s string, existing []int) ([]int, error) {
+	var partitions []int
+	seen := make(map[int]bool)
+	for _, field := range strings.Split(s, ",") {
+		p, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			return nil, fmt.Errorf("invalid partition %q", field)
+		}
+		if seen[p] {
+			return nil, fmt.Errorf("partition %d is listed more than once", p)
+		}
+		if !containsPartition(existing, p) {
+			return nil, fmt.Errorf("partition %d does not exist (%d partitions)", p, len(existing))
+		}
+		seen[p] = true
+		partitions = append(partitions, p)
+	}
+	return partitions, nil
+}
+
+// backupWriter 백업 형식별 기록기
+type backupWriter interface {
+	writeManifest(m BackupManifest) error
+	writeRecord(msg kafka.Message) error
+	writeEnd(records int64) error
+}
+
+// newBackupWriter 형식별 기록기 생성 (format은 ndjson 또는 binary)
+func newBackupWriter(format string, w io.Writer) backupWriter {
+	if format == "binary" {
+		return &binaryBackupWriter{w: w}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &ndjsonBackupWriter{enc: enc}
+}
+
+// ndjsonBackupWriter 줄마다 JSON 하나 (backup, 레코드들, end)
+type ndjsonBackupWriter struct {
+	enc *json.Encoder
+}
+
+func (w *ndjsonBackupWriter) writeManifest(m BackupManifest) error {
+	return w.enc.Encode(backupLine{Backup: &m})
+}
+
+func (w *ndjsonBackupWriter) writeRecord(msg kafka.Message) error {
+	rec := &BackupRecord{Partition: msg.Partition, Offset: msg.Offset, Timestamp: msg.Time}
+	rec.Key, rec.KeyEncoding = encodeBackupBytes(msg.Key)
+	rec.Value, rec.ValueEncoding = encodeBackupBytes(msg.Value)
+	for _, h := range msg.Headers {
+		header := BackupRecordHeader{Key: h.Key}
+		header.Value, header.Encoding = encodeBackupBytes(h.Value)
+		rec.Headers = append(rec.Headers, header)
+	}
+	return w.enc.Encode(backupLine{BackupRecord: rec})
+}
+
+func (w *ndjsonBackupWriter) writeEnd(records int64) error {
+	return w.enc.Encode(backupLine{End: &BackupEnd{Records: records}})
+}
+
+// binaryBackupWriter 바이너리 백업 (정수는 빅엔디언)
+//
+//	"KBAK" 버전(1) 매니페스트길이(4) 매니페스트JSON
+//	'R' 파티션(4) 오프셋(8) 타임스탬프ms(8) 키 값 헤더수(4) {헤더키 헤더값}...
+//	'E' 레코드수(8)
+//
+// 키, 값, 헤더는 길이(4) + 바이트이며 null은 길이 0xFFFFFFFF.
+type binaryBackupWriter struct {
+	w   io.Writer
+	buf []byte
+}
+
+func (w *binaryBackupWriter) writeManifest(m BackupManifest) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	buf := append(w.buf[:0], backupMagic...)
+	buf = append(buf, backupVersion)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(data)))
+	buf = append(buf, data...)
+	return w.flush(buf)
+}
+
+func (w *binaryBackupWriter) writeRecord(msg kafka.Message) error {
+	buf := append(w.buf[:0], backupTagRecord)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(msg.Partition))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(msg.Offset))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(msg.Time.UnixMilli()))
+	buf = appendBackupBytes(buf, msg.Key)
+	buf = appendBackupBytes(buf, msg.Value)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(msg.Headers)))
+	for _, h := range msg.Headers {
+		buf = appendBackupBytes(buf, []byte(h.Key))
+		buf = appendBackupBytes(buf, h.Value)
+	}
+	return w.flush(buf)
+}
+
+func (w *binaryBackupWriter) writeEnd(records int64) error {
+	buf := append(w.buf[:0], backupTagEnd)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(records))
+	return w.flush(buf)
+}
+
+// flush 버퍼 기록 (다음 항목에 버퍼 재사용)
+func (w *binaryBackupWriter) flush(buf []byte) error {
+	w.buf = buf
+	_, err := w.w.Write(buf)
+	return err
+}
+
+// appendBackupBytes 길이와 바이트 추가 (null은 길이 0xFFFFFFFF)
+func appendBackupBytes(buf, b []byte) []byte {
+	if b == nil {
+		return binary.BigEndian.AppendUint32(buf, backupNullLength)
+	}
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(b)))
+	return append(buf, b...)
+}
+
+// encodeBackupBytes NDJSON에 기록할 문자열과 인코딩 (UTF-8이 아니면 base64, null은 nil)
+func encodeBackupBytes(b []byte) (*string, string) {
+	if b == nil {
+		return nil, ""
+	}
+	if utf8.Valid(b) {
+		s := string(b)
+		return &s, ""
+	}
+	s := base64.StdEncoding.EncodeToString(b)
+	return &s, "base64"
+}
+
+// decodeBackupBytes encodeBackupBytes의 역변환
+func decodeBackupBytes(s *string, encoding string) ([]byte, error) {
+	if s == nil {
+		return nil, nil
+	}
+	switch encoding {
+	case "":
+		return []byte(*s), nil
+	case "base64":
+		return base64.StdEncoding.DecodeString(*s)
+	}
+	return nil, fmt.Errorf("unknown encoding %q", encoding)
+}
+
+// backupSource 백업 파일 복원용 파서 (파일 업로드 전송의 backup 형식)
+//
+// gzip을 푼 내용이 KBAK로 시작하면 바이너리, 아니면 NDJSON 백업으로 읽는다.
+// 기본으로 원본 파티션 번호와 타임스탬프를 유지하며, partitioning=balancer면
+// 파티션을 balancer로 다시 분배하고 timestamps=now면 전송 시각(또는 timestamp)을 쓴다.
+// 끝 표시가 없거나 레코드 수가 맞지 않으면 불완전한 파일로 보고 읽기를 중단한다.
+type backupSource struct {
+	manifest           BackupManifest
+	read               func() (kafka.Message, *BackupEnd, error)
+	records            int64
+	ended              bool
+	originalPartitions bool
+	originalTimestamps bool
+}
+
+// newBackupSource 백업 파서 생성 (매니페스트까지 읽음)
+func newBackupSource(r io.Reader, query url.Values) (*backupSource, error) {
+	s := &backupSource{}
+	switch query.Get("partitioning") {
+	case "", "original":
+		s.originalPartitions = true
+	case "balancer":
+	default:
+		return nil, fmt.Errorf("partitioning must be original or balancer")
+	}
+	switch query.Get("timestamps") {
+	case "", "original":
+		if query.Get("timestamp") != "" {
+			return nil, fmt.Errorf("timestamp requires timestamps=now")
+		}
+		s.originalTimestamps = true
+	case "now":
+	default:
+		return nil, fmt.Errorf("timestamps must be original or now")
+	}
+
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("backup file must be gzip-compressed: %v", err)
+	}
+	br := bufio.NewReader(zr)
+	magic, _ := br.Peek(len(backupMagic))
+	if string(magic) == backupMagic {
+		err = s.openBinary(br)
+	} else {
+		err = s.openNDJSON(br)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup file: %v", err)
+	}
+	if s.manifest.Version != backupVersion {
+		return nil, fmt.Errorf("unsupported backup version %d", s.manifest.Version)
+	}
+	return s, nil
+}
+
+func (s *backupSource) next() (BatchMessage, error) {
+	if s.ended {
+		return BatchMessage{}, io.EOF
+	}
+
+	msg, end, err := s.read()
+	var recErr *recordError
+	switch {
+	case err == io.EOF:
+		return BatchMessage{}, fmt.Errorf("backup file is incomplete: no end marker after %d records", s.records)
+	case errors.As(err, &recErr):
+		s.records++
+		return BatchMessage{}, err
+	case err != nil:
+		return BatchMessage{}, err
+	case end != nil:
+		s.ended = true
+		if end.Records != s.records {
+			return BatchMessage{}, fmt.Errorf("backup file has %d records but its end marker says %d", s.records, end.Records)
+		}
+		return BatchMessage{}, io.EOF
+	}
+	s.records++
+
+	m := BatchMessage{
+		Key:       string(msg.Key),
+		NullKey:   msg.Key == nil,
+		Value:     string(msg.Value),
+		NullValue: msg.Value == nil,
+	}
+	if s.originalPartitions {
+		partition := msg.Partition
+		m.Partition = &partition
+	}
+	if s.originalTimestamps {
+		timestamp := msg.Time
+		m.Timestamp = &timestamp
+	}
+	for _, h := range msg.Headers {
+		m.Headers = append(m.Headers, MessageHeader{Key: h.Key, Value: string(h.Value), NullValue: h.Value == nil})
+	}
+	return m, nil
+}
+
+// openNDJSON 첫 줄의 매니페스트를 읽고 레코드 파서 설정
+func (s *backupSource) openNDJSON(r io.Reader) error {
+	scanner := newDelimitedScanner(r, "\n")
+	nextLine := func() ([]byte, error) {
+		for scanner.Scan() {
+			if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+				return line, nil
+			}
+		}
+		return nil, scanErr(scanner)
+	}
+
+	line, err := nextLine()
+	if err != nil {
+		return err
+	}
+	var first backupLine
+	if err := json.Unmarshal(line, &first); err != nil || first.Backup == nil {
+		return errors.New("first line must be a backup manifest")
+	}
+	s.manifest = *first.Backup
+
+	s.read = func() (kafka.Message, *BackupEnd, error) {
+		line, err := nextLine()
+		if err != nil {
+			return kafka.Message{}, nil, err
+		}
+		var l backupLine
+		if err := json.Unmarshal(line, &l); err != nil {
+			return kafka.Message{}, nil, &recordError{err: fmt.Errorf("invalid JSON: %v", err)}
+		}
+		if l.End != nil {
+			return kafka.Message{}, l.End, nil
+		}
+		if l.BackupRecord == nil {
+			return kafka.Message{}, nil, &recordError{err: errors.New("line is neither a record nor an end marker")}
+		}
+		msg, err := l.BackupRecord.message()
+		if err != nil {
+			return kafka.Message{}, nil, &recordError{err: err}
+		}
+		return msg, nil, nil
+	}
+	return nil
+}
+
+// message NDJSON 레코드를 Kafka 메시지로 변환
+func (rec *BackupRecord) message() (kafka.Message, error) {
+	msg := kafka.Message{Partition: rec.Partition, Offset: rec.Offset, Time: rec.Timestamp}
+	var err error
+	if msg.Key, err = decodeBackupBytes(rec.Key, rec.KeyEncoding); err != nil {
+		return msg, fmt.Errorf("key: %v", err)
+	}
+	if msg.Value, err = decodeBackupBytes(rec.Value, rec.ValueEncoding); err != nil {
+		return msg, fmt.Errorf("value: %v", err)
+	}
+	for _, h := range rec.Headers {
+		value, err := decodeBackupBytes(h.Value, h.Encoding)
+		if err != nil {
+			return msg, fmt.Errorf("header %s: %v", h.Key, err)
+		}
+		msg.Headers = append(msg.Headers, kafka.Header{Key: h.Key, Value: value})
+	}
+	return msg, nil
+}
+
+// openBinary 매니페스트를 읽고 레코드 파서 설정
+func (s *backupSource) openBinary(r *bufio.Reader) error {
+	header := make([]byte, len(backupMagic)+1)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return err
+	}
+	if header[len(backupMagic)] != backupVersion {
+		return fmt.Errorf("unsupported backup version %d", header[len(backupMagic)])
+	}
+	manifest, err := readBackupBytes(r)
+	if err != nil || manifest == nil {
+		return errors.New("missing manifest")
+	}
+	if err := json.Unmarshal(manifest, &s.manifest); err != nil {
+		return fmt.Errorf("invalid manifest: %v", err)
+	}
+
+	s.read = func() (kafka.Message, *BackupEnd, error) {
+		tag, err := r.ReadByte()
+		if err != nil {
+			return kafka.Message{}, nil, err
+		}
+		switch tag {
+		case backupTagEnd:
+			var buf [8]byte
+			if _, err := io.ReadFull(r, buf[:]); err != nil {
+				return kafka.Message{}, nil, unexpectedEOF(err)
+			}
+			return kafka.Message{}, &BackupEnd{Records: int64(binary.BigEndian.Uint64(buf[:]))}, nil
+		case backupTagRecord:
+			msg, err := readBinaryBackupRecord(r)
+			return msg, nil, unexpectedEOF(err)
+		}
+		return kafka.Message{}, nil, fmt.Errorf("invalid entry tag 0x%02x", tag)
+	}
+	return nil
+}
+
+// readBinaryBackupRecord 'R' 다음의 레코드 본문 읽기
+func readBinaryBackupRecord(r *bufio.Reader) (kafka.Message, error) {
+	var fixed [20]byte
+	if _, err := io.ReadFull(r, fixed[:]); err != nil {
+		return kafka.Message{}, err
+	}
+	msg := kafka.Message{
+		Partition: int(int32(binary.BigEndian.Uint32(fixed[0:4]))),
+		Offset:    int64(binary.BigEndian.Uint64(fixed[4:12])),
+		Time:      time.UnixMilli(int64(binary.BigEndian.Uint64(fixed[12:20]))).UTC(),
+	}
+	var err error
+	if msg.Key, err = readBackupBytes(r); err != nil {
+		return msg, err
+	}
+	if msg.Value, err = readBackupBytes(r); err != nil {
+		return msg, err
+	}
+	var count [4]byte
+	if _, err := io.ReadFull(r, count[:]); err != nil {
+		return msg, err
+	}
+	for n := binary.BigEndian.Uint32(count[:]); n > 0; n-- {
+		key, err := readBackupBytes(r)
+		if err != nil {
+			return msg, err
+		}
+		value, err := readBackupBytes(r)
+		if err != nil {
+			return msg, err
+		}
+		msg.Headers = append(msg.Headers, kafka.Header{Key: string(key), Value: value})
+	}
+	return msg, nil
+}
+
+// readBackupBytes 길이와 바이트 읽기 (null이면 nil)
+func readBackupBytes(r io.Reader) ([]byte, error) {
+	var length [4]byte
+	if _, err := io.ReadFull(r, length[:]); err != nil {
+		return nil, err
+	}
+	n := binary.BigEndian.Uint32(length[:])
+	if n == backupNullLength {
+		return nil, nil
+	}
+	if n > uploadMaxRecordBytes {
+		return nil, fmt.Errorf("field of %d bytes exceeds the %d byte limit", n, uploadMaxRecordBytes)
+	}
+	b := make([]byte, n)
+	_, err := io.ReadFull(r, b)
+	return b, err
+}
+
+// unexpectedEOF 항목 중간에서 끝난 파일은 io.EOF 대신 io.ErrUnexpectedEOF
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,356 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend/kafkaext"
+
+	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/protocol/incrementalalterconfigs"
+)
+
+// clusterDefaultBroker 클러스터 전체 기본값을 나타내는 브로커 ID 자리 값
+const clusterDefaultBroker = "default"
+
+// brokerSpecificConfigs 브로커마다 다른 것이 정상인 설정 (드리프트 비교에서 제외)
+var brokerSpecificConfigs = map[string]bool{
+	"broker.id":                      true,
+	"node.id":                        true,
+	"broker.rack":                    true,
+	"listeners":                      true,
+	"advertised.listeners":           true,
+	"advertised.host.name":           true,
+	"advertised.port":                true,
+	"host.name":                      true,
+	"controller.listener.names":      true,
+	"listener.security.protocol.map": true,
+}
+
+// ConfigDrift 브로커 간 값이 다른 설정
+type ConfigDrift struct {
+	Name    string            `json:"name"`
+	Values  map[string]string `json:"values"`
+	Sources map[string]string `json:"sources"`
+}
+
+// GetBrokerConfig 브로커 설정 조회 (:id가 default면 클러스터 전체 동적 기본값)
+func GetBrokerConfig(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == clusterDefaultBroker {
+		entries, err := describeClusterDefaults()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": fmt.Sprintf("Failed to describe cluster default config: %v", err),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"broker":  clusterDefaultBroker,
+			"configs": entries,
+			"count":   len(entries),
+		})
+		return
+	}
+
+	if _, err := strconv.Atoi(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid broker id"})
+		return
+	}
+
+	entries, err := describeConfigs(kafka.ResourceTypeBroker, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to describe broker config: %v", err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"broker":  id,
+		"configs": entries,
+		"count":   len(entries),
+	})
+}
+
+// AlterBrokerConfig 브로커별 또는 클러스터 기본 동적 설정 변경 (dryRun이면 미리보기만)
+func AlterBrokerConfig(c *gin.Context) {
+	id := c.Param("id")
+
+	var req AlterConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var current []ConfigEntry
+	var err error
+	if id == clusterDefaultBroker {
+		current, err = clusterDefaultCandidates()
+	} else if _, convErr := strconv.Atoi(id); convErr != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid broker id"})
+		return
+	} else {
+		current, err = describeConfigs(kafka.ResourceTypeBroker, id)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to describe broker config: %v", err),
+		})
+		return
+	}
+
+	previews, validationErrors := previewConfigChanges(current, req.Configs)
+	if len(validationErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":  "Invalid config changes",
+			"errors": validationErrors,
+		})
+		return
+	}
+
+	if id == clusterDefaultBroker {
+		err = alterClusterDefaults(req.Configs, req.DryRun)
+	} else {
+		err = incrementalAlterConfigs(kafka.ResourceTypeBroker, id, req.Configs, req.DryRun)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("Failed to alter broker config: %v", err),
+		})
+		return
+	}
+
+	status := "success"
+	message := "Broker config altered successfully"
+	if req.DryRun {
+		status = "validated"
+		message = "Config changes are valid (dry run, nothing applied)"
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  status,
+		"broker":  id,
+		"dry_run": req.DryRun,
+		"changes": previews,
+		"message": message,
+	})
+}
+
+// GetBrokerConfigDrift 브로커 간 설정 차이 조회 (?brokers=1,2,3, 기본값은 전체 브로커)
+func GetBrokerConfigDrift(c *gin.Context) {
+	var brokerIDs []string
+	if v := c.Query("brokers"); v != "" {
+		for _, id := range strings.Split(v, ",") {
+			id = strings.TrimSpace(id)
+			if _, err := strconv.Atoi(id); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid broker id %q", id)})
+				return
+			}
+			brokerIDs = append(brokerIDs, id)
+		}
+	} else {
+		conn, err := kafka.Dial("tcp", kafkaBrokers)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": fmt.Sprintf("Failed to connect to Kafka: %v", err),
+			})
+			return
+		}
+		brokers, err := conn.Brokers()
+		conn.Close()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": fmt.Sprintf("Failed to get brokers: %v", err),
+			})
+			return
+		}
+		sort.Slice(brokers, func(i, j int) bool { return brokers[i].ID < brokers[j].ID })
+		for _, b := range brokers {
+			brokerIDs = append(brokerIDs, strconv.Itoa(b.ID))
+		}
+	}
+
+	if len(brokerIDs) < 2 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "at least two brokers are required to compare configs",
+		})
+		return
+	}
+
+	ignored := make(map[string]bool)
+	for name := range brokerSpecificConfigs {
+		ignored[name] = true
+	}
+	for _, name := range strings.Split(c.Query("ignore"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			ignored[name] = true
+		}
+	}
+
+	configs := make(map[string]map[string]ConfigEntry, len(brokerIDs))
+	names := make(map[string]bool)
+	for _, id := range brokerIDs {
+		entries, err := describeConfigs(kafka.ResourceTypeBroker, id)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": fmt.Sprintf("Failed to describe broker %s config: %v", id, err),
+			})
+			return
+		}
+		byName := make(map[string]ConfigEntry, len(entries))
+		for _, e := range entries {
+			byName[e.Name] = e
+			names[e.Name] = true
+		}
+		configs[id] = byName
+	}
+
+	drift := []ConfigDrift{}
+	for name := range names {
+		if ignored[name] {
+			continue
+		}
+
+		values := make(map[string]string, len(brokerIDs))
+		sources := make(map[string]string, len(brokerIDs))
+		distinct := make(map[string]bool)
+		sensitive := false
+		for _, id := range brokerIDs {
+			entry, ok := configs[id][name]
+			if !ok {
+				values[id] = ""
+				sources[id] = "missing"
+				distinct["\x00missing"] = true
+				continue
+			}
+			sensitive = sensitive || entry.Sensitive
+			values[id] = entry.Value
+			sources[id] = entry.Source
+			distinct[entry.Value] = true
+		}
+
+		// 민감한 설정은 값이 가려져 비교할 수 없음
+		if sensitive || len(distinct) < 2 {
+			continue
+		}
+
+		drift = append(drift, ConfigDrift{Name: name, Values: values, Sources: sources})
+	}
+
+	sort.Slice(drift, func(i, j int) bool { return drift[i].Name < drift[j].Name })
+
+	ignoredNames := make([]string, 0, len(ignored))
+	for name := range ignored {
+		ignoredNames = append(ignoredNames, name)
+	}
+	sort.Strings(ignoredNames)
+
+	c.JSON(http.StatusOK, gin.H{
+		"brokers": brokerIDs,
+		"drift":   drift,
+		"count":   len(drift),
+		"ignored": ignoredNames,
+	})
+}
+
+// describeClusterDefaults 클러스터 기본값으로 설정된 동적 설정 목록
+func describeClusterDefaults() ([]ConfigEntry, error) {
+	candidates, err := clusterDefaultCandidates()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []ConfigEntry{}
+	for _, e := range candidates {
+		if e.Source == "dynamic_default_broker" {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+// clusterDefaultCandidates 임의 브로커의 설정 목록을 기준으로 클러스터 기본값 항목 구성
+//
+// 클러스터 기본값은 브로커 설정의 synonym(dynamic_default_broker)으로 노출되므로,
+// 기본값이 있으면 그 값을, 없으면 빈 값과 unset 출처를 채운다.
+func clusterDefaultCandidates() ([]ConfigEntry, error) {
+	conn, err := kafka.Dial("tcp", kafkaBrokers)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	controller, err := conn.Controller()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := describeConfigs(kafka.ResourceTypeBroker, strconv.Itoa(controller.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	for i, e := range entries {
+		entries[i].Value = ""
+		entries[i].Source = "unset"
+		entries[i].IsDefault = false
+		for _, s := range e.Synonyms {
+			if s.Source == "dynamic_default_broker" {
+				entries[i].Value = s.Value
+				entries[i].Source = s.Source
+				break
+			}
+		}
+		entries[i].Synonyms = nil
+	}
+
+	return entries, nil
+}
+
+// alterClusterDefaults 클러스터 기본 동적 설정 변경 (브로커 리소스 이름이 빈 문자열)
+//
+// kafka-go는 브로커 리소스 이름을 숫자 ID로 해석해 라우팅하므로 직접 요청을 보낸다.
+func alterClusterDefaults(changes []ConfigChange, validateOnly bool) error {
+	resource := incrementalalterconfigs.RequestResource{
+		ResourceType: int8(kafka.ResourceTypeBroker),
+		ResourceName: "",
+	}
+	for _, change := range changes {
+		resource.Configs = append(resource.Configs, incrementalalterconfigs.RequestConfig{
+			Name:            change.Name,
+			Value:           change.Value,
+			ConfigOperation: int8(configOperations[normalizeConfigOp(change.Op)]),
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	msg, err := kafkaext.RoundTrip(ctx, kafkaBrokers, 0, &incrementalalterconfigs.Request{
+		Resources:    []incrementalalterconfigs.RequestResource{resource},
+		ValidateOnly: validateOnly,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, r := range msg.(*incrementalalterconfigs.Response).Responses {
+		if r.ErrorCode != 0 {
+			if r.ErrorMessage != "" {
+				return fmt.Errorf("%v: %s", kafka.Error(r.ErrorCode), r.ErrorMessage)
+			}
+			return kafka.Error(r.ErrorCode)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,819 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	// canaryMaxSamples 파티션별로 보관할 최대 표본 수 (구간 안에서도 오래된 것부터 버림)
+	canaryMaxSamples = 10000
+	// canaryMetadataRefresh 파티션 리더와 브로커 목록을 다시 확인하는 간격
+	canaryMetadataRefresh = 30 * time.Second
+	// canaryRetentionMs 카나리 토픽을 새로 만들 때의 보관 기간 (1시간)
+	canaryRetentionMs = "3600000"
+)
+
+// 카나리 상태 (심각한 순서)
+const (
+	canaryStatusNoData   = "no_data"
+	canaryStatusOK       = "ok"
+	canaryStatusWarning  = "warning"
+	canaryStatusCritical = "critical"
+)
+
+// CanaryConfig 지연 카나리 설정 (0인 값은 기본값 사용)
+type CanaryConfig struct {
+	// Topic 프로브 전용 토픽 (없으면 브로커 수만큼 파티션으로 생성)
+	Topic string
+	// Interval 파티션별 프로브 전송 간격 (기본 5초)
+	Interval time.Duration
+	// Timeout 이 시간 안에 소비되지 않은 프로브는 유실로 기록 (기본 30초)
+	Timeout time.Duration
+	// Window 백분위와 유실/오류 수를 계산하는 최근 구간 (기본 5분)
+	Window time.Duration
+	// WarnLatency, CriticalLatency end-to-end p99 경고/심각 기준 (기본 500ms, 2초)
+	WarnLatency     time.Duration
+	CriticalLatency time.Duration
+}
+
+// CanaryLatency 지연 백분위 (밀리초)
+type CanaryLatency struct {
+	Samples int     `json:"samples"`
+	P50Ms   float64 `json:"p50_ms"`
+	P90Ms   float64 `json:"p90_ms"`
+	P99Ms   float64 `json:"p99_ms"`
+	MaxMs   float64 `json:"max_ms"`
+	// sumMs 표본 지연 합계 (Prometheus summary의 _sum, JSON 응답에는 없음)
+	sumMs float64
+}
+
+// CanaryThresholds 상태 판정 기준
+type CanaryThresholds struct {
+	WarningMs  float64 `json:"warning_ms"`
+	CriticalMs float64 `json:"critical_ms"`
+	TimeoutMs  float64 `json:"timeout_ms"`
+}
+
+// CanaryPartitionStats 파티션별 카나리 결과
+type CanaryPartitionStats struct {
+	Partition      int           `json:"partition"`
+	Leader         int           `json:"leader"`
+	Status         string        `json:"status"`
+	EndToEnd       CanaryLatency `json:"end_to_end"`
+	ProduceAck     CanaryLatency `json:"produce_ack"`
+	Sent           int64         `json:"sent"`
+	Received       int64         `json:"received"`
+	Lost           int           `json:"lost"`
+	ProduceErrors  int           `json:"produce_errors"`
+	LastError      string        `json:"last_error,omitempty"`
+	LastReceivedAt *time.Time    `json:"last_received_at,omitempty"`
+}
+
+// CanaryBrokerStats 리더 브로커별 카나리 결과 (프로브를 보낼 때의 리더 기준)
+type CanaryBrokerStats struct {
+	Broker        int           `json:"broker"`
+	Partitions    []int         `json:"partitions"`
+	Status        string        `json:"status"`
+	EndToEnd      CanaryLatency `json:"end_to_end"`
+	ProduceAck    CanaryLatency `json:"produce_ack"`
+	Lost          int           `json:"lost"`
+	ProduceErrors int           `json:"produce_errors"`
+}
+
+// CanaryAlert 경고 입력 (기준을 넘은 파티션/브로커)
+type CanaryAlert struct {
+	Severity  string  `json:"severity"` // warning, critical
+	Scope     string  `json:"scope"`    // partition, broker
+	Partition *int    `json:"partition,omitempty"`
+	Broker    *int    `json:"broker,omitempty"`
+	Metric    string  `json:"metric"` // end_to_end_p99_ms, lost_probes, produce_errors
+	Value     float64 `json:"value"`
+	Threshold float64 `json:"threshold"`
+	Message   string  `json:"message"`
+}
+
+// CanaryStatus 카나리 조회 응답
+type CanaryStatus struct {
+	Topic            string                 `json:"topic"`
+	Instance         string                 `json:"instance"`
+	StartedAt        time.Time              `json:"started_at"`
+	IntervalMs       int64                  `json:"interval_ms"`
+	WindowSeconds    int64                  `json:"window_seconds"`
+	Thresholds       CanaryThresholds       `json:"thresholds"`
+	Status           string                 `json:"status"`
+	Stopped          bool                   `json:"stopped"`
+	LastError        string                 `json:"last_error,omitempty"`
+	EndToEnd         CanaryLatency          `json:"end_to_end"`
+	ProduceAck       CanaryLatency          `json:"produce_ack"`
+	Partitions       []CanaryPartitionStats `json:"partitions"`
+	Brokers          []CanaryBrokerStats    `json:"brokers"`
+	UncoveredBrokers []int                  `json:"uncovered_brokers,omitempty"`
+	Alerts           []CanaryAlert          `json:"alerts"`
+}
+
+// canaryProbe 프로브 메시지 값
+type canaryProbe struct {
+	Instance string `json:"instance"`
+	Seq      int64  `json:"seq"`
+	SentAt   int64  `json:"sent_at"` // UnixNano
+}
+
+// canarySample 지연 표본 또는 유실/오류 발생 기록 (latency 0)
+type canarySample struct {
+	at      time.Time
+	leader  int
+	latency time.Duration
+}
+
+// canaryPartition 파티션별 프로브 상태
+type canaryPartition struct {
+	id       int
+	leader   int
+	seq      int64
+	inflight bool
+	pending  map[int64]canarySample // seq → 전송 시각과 리더
+
+	endToEnd   []canarySample
+	produceAck []canarySample
+	lost       []canarySample
+	errors     []canarySample
+
+	sent         int64
+	received     int64
+	lastError    string
+	lastReceived time.Time
+}
+
+// canary 실행 중인 카나리 (프로세스당 하나)
+var canary struct {
+	mu         sync.Mutex
+	started    bool
+	cfg        CanaryConfig
+	instance   string
+	startedAt  time.Time
+	brokers    []int
+	partitions map[int]*canaryPartition
+	lastError  string
+	// stopped 토픽 정책 위반 등으로 카나리가 멈춤 (프로세스를 다시 시작해야 재개)
+	stopped bool
+}
+
+// StartCanary 카나리 시작 (프로브 전송과 소비는 백그라운드에서 계속 실행)
+//
+// 프로브마다 전송 시각을 담아 파티션별로 보내고, 같은 파티션을 읽는 Reader가
+// 받은 시각과의 차이를 end-to-end 지연으로, 전송 요청이 acks=all로 확인되기까지의
+// 시간을 produce ack 지연으로 기록한다. 키에 인스턴스 ID를 넣어 여러 대시보드가
+// 같은 토픽을 써도 자신의 프로브만 집계한다.
+func StartCanary(cfg CanaryConfig) error {
+	if cfg.Topic == "" {
+		return errors.New("canary topic is required")
+	}
+	defaults := []struct {
+		value *time.Duration
+		def   time.Duration
+	}{
+		{&cfg.Interval, 5 * time.Second},
+		{&cfg.Timeout, 30 * time.Second},
+		{&cfg.Window, 5 * time.Minute},
+		{&cfg.WarnLatency, 500 * time.Millisecond},
+		{&cfg.CriticalLatency, 2 * time.Second},
+	}
+	for _, d := range defaults {
+		if *d.value < 0 {
+			return errors.New("canary durations must not be negative")
+		}
+		if *d.value == 0 {
+			*d.value = d.def
+		}
+	}
+	if cfg.CriticalLatency < cfg.WarnLatency {
+		return fmt.Errorf("canary critical latency %s is less than warning latency %s", cfg.CriticalLatency, cfg.WarnLatency)
+	}
+	if cfg.Window < cfg.Interval {
+		return fmt.Errorf("canary window %s is shorter than interval %s", cfg.Window, cfg.Interval)
+	}
+
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return err
+	}
+
+	canary.mu.Lock()
+	defer canary.mu.Unlock()
+	if canary.started {
+		return errors.New("canary is already running")
+	}
+	canary.started = true
+	canary.cfg = cfg
+	canary.instance = hex.EncodeToString(b)
+	canary.startedAt = time.Now().UTC()
+	canary.partitions = make(map[int]*canaryPartition)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go runCanary(ctx, cancel, cfg, canary.instance)
+	return nil
+}
+
+// runCanary 메타데이터 확인, 프로브 전송, 유실 판정 반복 (토픽 정책 위반 시 중단)
+func runCanary(ctx context.Context, stop context.CancelFunc, cfg CanaryConfig, instance string) {
+	client := newKafkaClient()
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+
+	var refreshed time.Time
+	for {
+		if time.Since(refreshed) >= canaryMetadataRefresh {
+			ready, err := refreshCanaryTopic(ctx, client, cfg, instance)
+			setCanaryError(err)
+			var policyErr *policyViolationError
+			if errors.As(err, &policyErr) {
+				log.Printf("Canary: stopped: %v", err)
+				stop()
+				canary.mu.Lock()
+				canary.stopped = true
+				canary.mu.Unlock()
+				return
+			}
+			if err != nil {
+				log.Printf("Canary: %v", err)
+			}
+			if ready {
+				refreshed = time.Now()
+			}
+		}
+
+		// Reader가 마지막 오프셋을 확인할 시간을 주기 위해 첫 전송도 한 간격 뒤에 시작
+		<-ticker.C
+		sendCanaryProbes(client, cfg, instance)
+		expireCanaryProbes(cfg)
+	}
+}
+
+// refreshCanaryTopic 토픽이 없으면 만들고, 파티션이 브로커 수보다 적으면 늘린 뒤 리더 갱신
+//
+// 토픽을 만들거나 파티션을 늘린 경우 다음 간격에 다시 확인하도록 ready=false를 돌려준다.
+// 만들거나 늘릴 토픽이 토픽 정책을 위반하면 *policyViolationError를 돌려준다.
+func refreshCanaryTopic(stop context.Context, client *kafka.Client, cfg CanaryConfig, instance string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	meta, err := client.Metadata(ctx, &kafka.MetadataRequest{Topics: []string{cfg.Topic}})
+	if err != nil {
+		return false, fmt.Errorf("failed to read metadata: %w", err)
+	}
+	brokers := make([]int, len(meta.Brokers))
+	for i, b := range meta.Brokers {
+		brokers[i] = b.ID
+	}
+	sort.Ints(brokers)
+
+	if len(meta.Topics) == 0 || errors.Is(meta.Topics[0].Error, kafka.UnknownTopicOrPartition) {
+		partitions, replicationFactor := max(len(brokers), 1), min(max(len(brokers), 1), 3)
+		configs := map[string]string{"retention.ms": canaryRetentionMs}
+
+		violations := topicPolicy.checkTopic(cfg.Topic, partitions, replicationFactor)
+		configViolations, err := topicPolicy.checkConfigs(replicationFactor, configs)
+		if err != nil {
+			return false, fmt.Errorf("failed to check topic policy: %w", err)
+		}
+		if violations = append(violations, configViolations...); len(violations) > 0 {
+			return false, &policyViolationError{topic: cfg.Topic, violations: violations}
+		}
+
+		resp, err := client.CreateTopics(ctx, &kafka.CreateTopicsRequest{
+			Topics: []kafka.TopicConfig{{
+				Topic:             cfg.Topic,
+				NumPartitions:     partitions,
+				ReplicationFactor: replicationFactor,
+				ConfigEntries:     toConfigEntries(configs),
+			}},
+		})
+		if err == nil {
+			err = resp.Errors[cfg.Topic]
+		}
+		if err != nil && !errors.Is(err, kafka.TopicAlreadyExists) {
+			return false, fmt.Errorf("failed to create topic %s: %w", cfg.Topic, err)
+		}
+		log.Printf("Canary: created topic %s with %d partitions", cfg.Topic, partitions)
+		return false, nil
+	}
+	topic := meta.Topics[0]
+	if topic.Error != nil {
+		return false, fmt.Errorf("failed to read topic %s: %w", cfg.Topic, topic.Error)
+	}
+
+	// 모든 브로커가 리더인 파티션을 갖도록 파티션 수를 브로커 수 이상으로 유지
+	if len(topic.Partitions) < len(brokers) {
+		if violations := topicPolicy.checkPartitions(len(brokers)); len(violations) > 0 {
+			return false, &policyViolationError{topic: cfg.Topic, violations: violations}
+		}
+		resp, err := client.CreatePartitions(ctx, &kafka.CreatePartitionsRequest{
+			Topics: []kafka.TopicPartitionsConfig{{Name: cfg.Topic, Count: int32(len(brokers))}},
+		})
+		if err == nil {
+			err = resp.Errors[cfg.Topic]
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to increase partitions of %s to %d: %w", cfg.Topic, len(brokers), err)
+		}
+		log.Printf("Canary: increased partitions of %s to %d", cfg.Topic, len(brokers))
+		return false, nil
+	}
+
+	canary.mu.Lock()
+	defer canary.mu.Unlock()
+	canary.brokers = brokers
+	for _, p := range topic.Partitions {
+		partition, ok := canary.partitions[p.ID]
+		if !ok {
+			partition = &canaryPartition{id: p.ID, pending: make(map[int64]canarySample)}
+			canary.partitions[p.ID] = partition
+			go consumeCanaryPartition(stop, cfg.Topic, p.ID, instance)
+		}
+		partition.leader = p.Leader.ID
+	}
+	return true, nil
+}
+
+// sendCanaryProbes 전송 중이 아닌 모든 파티션에 프로브 전송
+func sendCanaryProbes(client *kafka.Client, cfg CanaryConfig, instance string) {
+	canary.mu.Lock()
+	defer canary.mu.Unlock()
+
+	for _, p := range canary.partitions {
+		if p.inflight {
+			continue // 이전 프로브의 응답 대기 중 (지연은 produce ack 표본에 반영됨)
+		}
+		p.inflight = true
+		p.seq++
+		p.sent++
+		// 응답보다 소비가 먼저 될 수 있으므로 전송 전에 등록
+		sent := canarySample{at: time.Now(), leader: p.leader}
+		p.pending[p.seq] = sent
+		go p.probe(client, cfg, instance, p.seq, sent)
+	}
+}
+
+// probe 프로브 하나를 acks=all로 전송하고 응답 지연 기록
+func (p *canaryPartition) probe(client *kafka.Client, cfg CanaryConfig, instance string, seq int64, sent canarySample) {
+	value, _ := json.Marshal(canaryProbe{Instance: instance, Seq: seq, SentAt: sent.at.UnixNano()})
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+	resp, err := client.Produce(ctx, &kafka.ProduceRequest{
+		Topic:        cfg.Topic,
+		Partition:    p.id,
+		RequiredAcks: kafka.RequireAll,
+		Records: kafka.NewRecordReader(kafka.Record{
+			Time:  sent.at,
+			Key:   kafka.NewBytes([]byte(instance)),
+			Value: kafka.NewBytes(value),
+		}),
+	})
+	if err == nil {
+		err = resp.Error
+	}
+	acked := time.Now()
+
+	canary.mu.Lock()
+	defer canary.mu.Unlock()
+	p.inflight = false
+	if err != nil {
+		delete(p.pending, seq)
+		p.errors = appendCanarySample(p.errors, canarySample{at: acked, leader: sent.leader})
+		p.lastError = fmt.Sprintf("produce to leader %d: %v", sent.leader, err)
+		return
+	}
+	p.produceAck = appendCanarySample(p.produceAck, canarySample{at: acked, leader: sent.leader, latency: acked.Sub(sent.at)})
+}
+
+// consumeCanaryPartition 파티션의 새 프로브를 읽어 end-to-end 지연 기록 (stop이 끝나면 종료)
+func consumeCanaryPartition(stop context.Context, topic string, partition int, instance string) {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:   []string{kafkaBrokers},
+		Topic:     topic,
+		Partition: partition,
+		MinBytes:  1,
+		MaxBytes:  1e6,
+		MaxWait:   250 * time.Millisecond,
+	})
+	defer reader.Close()
+	reader.SetOffset(kafka.LastOffset)
+
+	for {
+		msg, err := reader.ReadMessage(stop)
+		if stop.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Printf("Canary: failed to read %s[%d]: %v", topic, partition, err)
+			time.Sleep(time.Second)
+			continue
+		}
+		received := time.Now()
+
+		var probe canaryProbe
+		if string(msg.Key) != instance || json.Unmarshal(msg.Value, &probe) != nil || probe.Instance != instance {
+			continue // 다른 인스턴스의 프로브
+		}
+
+		canary.mu.Lock()
+		p := canary.partitions[partition]
+		sent, ok := p.pending[probe.Seq]
+		if ok {
+			delete(p.pending, probe.Seq)
+		} else {
+			sent.leader = p.leader // 이미 유실로 기록된 늦은 프로브
+		}
+		p.received++
+		p.lastReceived = received
+		p.endToEnd = appendCanarySample(p.endToEnd, canarySample{
+			at:      received,
+			leader:  sent.leader,
+			latency: received.Sub(time.Unix(0, probe.SentAt)),
+		})
+		canary.mu.Unlock()
+	}
+}
+
+// expireCanaryProbes 제한 시간 안에 소비되지 않은 프로브를 유실로 기록하고 구간이 지난 표본 정리
+func expireCanaryProbes(cfg CanaryConfig) {
+	canary.mu.Lock()
+	defer canary.mu.Unlock()
+
+	now := time.Now()
+	cutoff := now.Add(-cfg.Window)
+	for _, p := range canary.partitions {
+		for seq, sent := range p.pending {
+			if now.Sub(sent.at) >= cfg.Timeout {
+				delete(p.pending, seq)
+				p.lost = appendCanarySample(p.lost, canarySample{at: now, leader: sent.leader})
+				p.lastError = fmt.Sprintf("probe %d was not consumed within %s", seq, cfg.Timeout)
+			}
+		}
+		p.endToEnd = pruneCanarySamples(p.endToEnd, cutoff)
+		p.produceAck = pruneCanarySamples(p.produceAck, cutoff)
+		p.lost = pruneCanarySamples(p.lost, cutoff)
+		p.errors = pruneCanarySamples(p.errors, cutoff)
+	}
+}
+
+// setCanaryError 카나리 전체 오류 기록 (nil이면 지움)
+func setCanaryError(err error) {
+	canary.mu.Lock()
+	defer canary.mu.Unlock()
+	canary.lastError = ""
+	if err != nil {
+		canary.lastError = err.Error()
+	}
+}
+
+// appendCanarySample 표본 추가 (최대 개수를 넘으면 가장 오래된 것부터 버림)
+func appendCanarySample(samples []canarySample, s canarySample) []canarySample {
+	if len(samples) >= canaryMaxSamples {
+		samples = append(samples[:0], samples[len(samples)-canaryMaxSamples+1:]...)
+	}
+	return append(samples, s)
+}
+
+// pruneCanarySamples cutoff 이전 표본 제거 (시간 순으로 쌓이므로 앞에서부터)
+func pruneCanarySamples(samples []canarySample, cutoff time.Time) []canarySample {
+	i := sort.Search(len(samples), func(i int) bool { return !samples[i].at.Before(cutoff) })
+	return append(samples[:0], samples[i:]...)
+}
+
+// GetCanaryMetrics 카나리 지연 백분위와 경고 입력 조회 (format=prometheus면 텍스트 형식)
+func GetCanaryMetrics(c *gin.Context) {
+	status, ok := canaryStatus()
+	if !ok {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Latency canary is not enabled (set CANARY_TOPIC)"})
+		return
+	}
+
+	switch c.Query("format") {
+	case "", "json":
+		c.JSON(http.StatusOK, status)
+	case "prometheus":
+		c.Data(http.StatusOK, "text/plain; version=0.0.4; charset=utf-8", []byte(status.prometheus()))
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown format %q (expected json or prometheus)", c.Query("format"))})
+	}
+}
+
+// canaryStatus 현재 구간의 카나리 결과 (카나리가 실행 중이 아니면 ok=false)
+func canaryStatus() (CanaryStatus, bool) {
+	canary.mu.Lock()
+	defer canary.mu.Unlock()
+	if !canary.started {
+		return CanaryStatus{}, false
+	}
+
+	cfg := canary.cfg
+	cutoff := time.Now().Add(-cfg.Window)
+	s := CanaryStatus{
+		Topic:         cfg.Topic,
+		Instance:      canary.instance,
+		StartedAt:     canary.startedAt,
+		IntervalMs:    cfg.Interval.Milliseconds(),
+		WindowSeconds: int64(cfg.Window.Seconds()),
+		Thresholds: CanaryThresholds{
+			WarningMs:  durationMs(cfg.WarnLatency),
+			CriticalMs: durationMs(cfg.CriticalLatency),
+			TimeoutMs:  durationMs(cfg.Timeout),
+		},
+		Stopped:    canary.stopped,
+		LastError:  canary.lastError,
+		Partitions: []CanaryPartitionStats{},
+		Brokers:    []CanaryBrokerStats{},
+		Alerts:     []CanaryAlert{},
+	}
+
+	ids := make([]int, 0, len(canary.partitions))
+	for id := range canary.partitions {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	// 리더 브로커별 표본 (프로브를 보낼 때의 리더 기준)
+	type brokerSamples struct {
+		partitions           []int
+		endToEnd, produceAck []canarySample
+		lost, errors         int
+	}
+	brokers := make(map[int]*brokerSamples)
+	brokerOf := func(id int) *brokerSamples {
+		if brokers[id] == nil {
+			brokers[id] = &brokerSamples{partitions: []int{}}
+		}
+		return brokers[id]
+	}
+	for _, id := range canary.brokers {
+		brokerOf(id)
+	}
+
+	var allEndToEnd, allProduceAck []canarySample
+	for _, id := range ids {
+		p := canary.partitions[id]
+		endToEnd := samplesSince(p.endToEnd, cutoff)
+		produceAck := samplesSince(p.produceAck, cutoff)
+		lost := samplesSince(p.lost, cutoff)
+		errs := samplesSince(p.errors, cutoff)
+
+		ps := CanaryPartitionStats{
+			Partition:     id,
+			Leader:        p.leader,
+			EndToEnd:      canaryLatency(endToEnd),
+			ProduceAck:    canaryLatency(produceAck),
+			Sent:          p.sent,
+			Received:      p.received,
+			Lost:          len(lost),
+			ProduceErrors: len(errs),
+			LastError:     p.lastError,
+		}
+		if !p.lastReceived.IsZero() {
+			t := p.lastReceived.UTC()
+			ps.LastReceivedAt = &t
+		}
+		partition := id
+		var alerts []CanaryAlert
+		ps.Status, alerts = cfg.evaluate(ps.EndToEnd, ps.Lost, ps.ProduceErrors)
+		for _, a := range alerts {
+			a.Scope, a.Partition = "partition", &partition
+			a.Message = fmt.Sprintf("partition %d (leader %d): %s", id, p.leader, a.Message)
+			s.Alerts = append(s.Alerts, a)
+		}
+		s.Partitions = append(s.Partitions, ps)
+
+		brokerOf(p.leader).partitions = append(brokerOf(p.leader).partitions, id)
+		for _, sample := range endToEnd {
+			brokerOf(sample.leader).endToEnd = append(brokerOf(sample.leader).endToEnd, sample)
+		}
+		for _, sample := range produceAck {
+			brokerOf(sample.leader).produceAck = append(brokerOf(sample.leader).produceAck, sample)
+		}
+		for _, sample := range lost {
+			brokerOf(sample.leader).lost++
+		}
+		for _, sample := range errs {
+			brokerOf(sample.leader).errors++
+		}
+		allEndToEnd = append(allEndToEnd, endToEnd...)
+		allProduceAck = append(allProduceAck, produceAck...)
+	}
+
+	brokerIDs := make([]int, 0, len(brokers))
+	for id := range brokers {
+		brokerIDs = append(brokerIDs, id)
+	}
+	sort.Ints(brokerIDs)
+	for _, id := range brokerIDs {
+		b := brokers[id]
+		if id < 0 {
+			continue // 리더가 없던 동안의 프로브는 파티션 결과에만 반영
+		}
+		bs := CanaryBrokerStats{
+			Broker:        id,
+			Partitions:    b.partitions,
+			EndToEnd:      canaryLatency(b.endToEnd),
+			ProduceAck:    canaryLatency(b.produceAck),
+			Lost:          b.lost,
+			ProduceErrors: b.errors,
+		}
+		broker := id
+		var alerts []CanaryAlert
+		bs.Status, alerts = cfg.evaluate(bs.EndToEnd, bs.Lost, bs.ProduceErrors)
+		for _, a := range alerts {
+			a.Scope, a.Broker = "broker", &broker
+			a.Message = fmt.Sprintf("broker %d: %s", id, a.Message)
+			s.Alerts = append(s.Alerts, a)
+		}
+		if len(b.partitions) == 0 && slices.Contains(canary.brokers, id) {
+			s.UncoveredBrokers = append(s.UncoveredBrokers, id)
+		}
+		s.Brokers = append(s.Brokers, bs)
+	}
+
+	s.EndToEnd = canaryLatency(allEndToEnd)
+	s.ProduceAck = canaryLatency(allProduceAck)
+	s.Status = canaryStatusNoData
+	for _, p := range s.Partitions {
+		s.Status = worseCanaryStatus(s.Status, p.Status)
+	}
+	return s, true
+}
+
+// evaluate 표본과 유실/오류 수로 상태와 경고 판정 (표본이 없으면 no_data)
+func (cfg CanaryConfig) evaluate(endToEnd CanaryLatency, lost, produceErrors int) (string, []CanaryAlert) {
+	var alerts []CanaryAlert
+	if produceErrors > 0 {
+		alerts = append(alerts, CanaryAlert{
+			Severity: canaryStatusCritical, Metric: "produce_errors", Value: float64(produceErrors),
+			Message: fmt.Sprintf("%d probe(s) failed to produce", produceErrors),
+		})
+	}
+	if lost > 0 {
+		alerts = append(alerts, CanaryAlert{
+			Severity: canaryStatusCritical, Metric: "lost_probes", Value: float64(lost),
+			Message: fmt.Sprintf("%d probe(s) were not consumed within %s", lost, cfg.Timeout),
+		})
+	}
+	if endToEnd.Samples > 0 {
+		p99 := endToEnd.P99Ms
+		switch {
+		case p99 >= durationMs(cfg.CriticalLatency):
+			alerts = append(alerts, CanaryAlert{
+				Severity: canaryStatusCritical, Metric: "end_to_end_p99_ms", Value: p99, Threshold: durationMs(cfg.CriticalLatency),
+				Message: fmt.Sprintf("end-to-end p99 %.2fms exceeds %s", p99, cfg.CriticalLatency),
+			})
+		case p99 >= durationMs(cfg.WarnLatency):
+			alerts = append(alerts, CanaryAlert{
+				Severity: canaryStatusWarning, Metric: "end_to_end_p99_ms", Value: p99, Threshold: durationMs(cfg.WarnLatency),
+				Message: fmt.Sprintf("end-to-end p99 %.2fms exceeds %s", p99, cfg.WarnLatency),
+			})
+		}
+	}
+
+	status := canaryStatusNoData
+	if endToEnd.Samples > 0 {
+		status = canaryStatusOK
+	}
+	for _, a := range alerts {
+		status = worseCanaryStatus(status, a.Severity)
+	}
+	return status, alerts
+}
+
+// worseCanaryStatus 두 상태 중 더 심각한 상태
+func worseCanaryStatus(a, b string) string {
+	rank := map[string]int{canaryStatusNoData: 0, canaryStatusOK: 1, canaryStatusWarning: 2, canaryStatusCritical: 3}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
+// samplesSince cutoff 이후 표본 (아직 정리되지 않은 표본 제외)
+func samplesSince(samples []canarySample, cutoff time.Time) []canarySample {
+	i := sort.Search(len(samples), func(i int) bool { return !samples[i].at.Before(cutoff) })
+	return samples[i:]
+}
+
+// canaryLatency 표본의 지연 백분위 (nearest-rank)
+func canaryLatency(samples []canarySample) CanaryLatency {
+	l := CanaryLatency{Samples: len(samples)}
+	if len(samples) == 0 {
+		return l
+	}
+	sorted := make([]time.Duration, len(samples))
+	for i, s := range samples {
+		sorted[i] = s.latency
+		l.sumMs += durationMs(s.latency)
+	}
+	sort.Slice(sorted, func(a, b int) bool { return sorted[a] < sorted[b] })
+	percentile := func(p float64) float64 {
+		return durationMs(sorted[int(math.Ceil(p*float64(len(sorted))))-1])
+	}
+	l.P50Ms = percentile(0.50)
+	l.P90Ms = percentile(0.90)
+	l.P99Ms = percentile(0.99)
+	l.MaxMs = durationMs(sorted[len(sorted)-1])
+	return l
+}
+
+// prometheus Prometheus 텍스트 형식 (경고 규칙 입력용, 상태는 0=ok/no_data, 1=warning, 2=critical)
+func (s CanaryStatus) prometheus() string {
+	var b strings.Builder
+	statusValue := map[string]int{canaryStatusNoData: 0, canaryStatusOK: 0, canaryStatusWarning: 1, canaryStatusCritical: 2}
+	topic := strconv.Quote(s.Topic)
+
+	family := func(name, help string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+	}
+	// 지연 분포는 quantile 레이블을 쓰므로 summary로 선언 (창 안의 표본 기준 _sum, _count 포함)
+	summary := func(name, help string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s summary\n", name, help, name)
+	}
+	latency := func(name, labels string, l CanaryLatency) {
+		if l.Samples == 0 {
+			return
+		}
+		for _, q := range []struct {
+			quantile string
+			value    float64
+		}{{"0.5", l.P50Ms}, {"0.9", l.P90Ms}, {"0.99", l.P99Ms}, {"1", l.MaxMs}} {
+			fmt.Fprintf(&b, "%s{%s,quantile=%q} %g\n", name, labels, q.quantile, q.value)
+		}
+		fmt.Fprintf(&b, "%s_sum{%s} %g\n", name, labels, l.sumMs)
+		fmt.Fprintf(&b, "%s_count{%s} %d\n", name, labels, l.Samples)
+	}
+	partitionLabels := func(p CanaryPartitionStats) string {
+		return fmt.Sprintf("topic=%s,partition=\"%d\",leader=\"%d\"", topic, p.Partition, p.Leader)
+	}
+	brokerLabels := func(bs CanaryBrokerStats) string {
+		return fmt.Sprintf("topic=%s,broker=\"%d\"", topic, bs.Broker)
+	}
+
+	family("kafka_canary_status", "Overall canary status (0=ok or no data, 1=warning, 2=critical).")
+	fmt.Fprintf(&b, "kafka_canary_status{topic=%s} %d\n", topic, statusValue[s.Status])
+
+	stopped := 0
+	if s.Stopped {
+		stopped = 1
+	}
+	family("kafka_canary_stopped", "Whether the canary stopped because its topic would violate the topic policy (1=stopped).")
+	fmt.Fprintf(&b, "kafka_canary_stopped{topic=%s} %d\n", topic, stopped)
+
+	summary("kafka_canary_partition_end_to_end_latency_ms", "End-to-end probe latency per partition in the window.")
+	for _, p := range s.Partitions {
+		latency("kafka_canary_partition_end_to_end_latency_ms", partitionLabels(p), p.EndToEnd)
+	}
+	summary("kafka_canary_partition_produce_ack_latency_ms", "Produce acknowledgement (acks=all) latency per partition in the window.")
+	for _, p := range s.Partitions {
+		latency("kafka_canary_partition_produce_ack_latency_ms", partitionLabels(p), p.ProduceAck)
+	}
+	for _, m := range []struct {
+		name, help string
+		value      func(CanaryPartitionStats) int
+	}{
+		{"kafka_canary_partition_samples", "End-to-end latency samples per partition in the window.", func(p CanaryPartitionStats) int { return p.EndToEnd.Samples }},
+		{"kafka_canary_partition_lost_probes", "Probes not consumed within the timeout per partition in the window.", func(p CanaryPartitionStats) int { return p.Lost }},
+		{"kafka_canary_partition_produce_errors", "Failed probe produce requests per partition in the window.", func(p CanaryPartitionStats) int { return p.ProduceErrors }},
+		{"kafka_canary_partition_status", "Canary status per partition (0=ok or no data, 1=warning, 2=critical).", func(p CanaryPartitionStats) int { return statusValue[p.Status] }},
+	} {
+		family(m.name, m.help)
+		for _, p := range s.Partitions {
+			fmt.Fprintf(&b, "%s{%s} %d\n", m.name, partitionLabels(p), m.value(p))
+		}
+	}
+
+	summary("kafka_canary_broker_end_to_end_latency_ms", "End-to-end probe latency per leader broker in the window.")
+	for _, bs := range s.Brokers {
+		latency("kafka_canary_broker_end_to_end_latency_ms", brokerLabels(bs), bs.EndToEnd)
+	}
+	summary("kafka_canary_broker_produce_ack_latency_ms", "Produce acknowledgement (acks=all) latency per leader broker in the window.")
+	for _, bs := range s.Brokers {
+		latency("kafka_canary_broker_produce_ack_latency_ms", brokerLabels(bs), bs.ProduceAck)
+	}
+	family("kafka_canary_broker_status", "Canary status per leader broker (0=ok or no data, 1=warning, 2=critical).")
+	for _, bs := range s.Brokers {
+		fmt.Fprintf(&b, "kafka_canary_broker_status{%s} %d\n", brokerLabels(bs), statusValue[bs.Status])
+	}
+	return b.String()
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// 클러스터 메타데이터 관리 방식
+const (
+	clusterModeKRaft     = "kraft"
+	clusterModeZooKeeper = "zookeeper"
+)
+
+// describeQuorumAPIKey DescribeQuorum API 키 (KRaft 모드 브로커만 노출)
+const describeQuorumAPIKey = 55
+
+// fetchAPIKey Fetch API 키
+const fetchAPIKey = 1
+
+// kafkaVersionsByFetch Fetch API 최대 버전별로 처음 지원한 Kafka 버전 (내림차순)
+//
+// 브로커는 자신의 Kafka 버전을 알려주지 않으므로 API 버전으로 최소 버전을 추정한다.
+var kafkaVersionsByFetch = []struct {
+	maxVersion int
+	version    string
+}{
+	{17, "3.9"},
+	{16, "3.7"},
+	{15, "3.5"},
+	{13, "3.1"},
+	{12, "2.7"},
+	{11, "2.3"},
+	{10, "2.1"},
+	{8, "2.0"},
+	{7, "1.1"},
+	{6, "1.0"},
+	{4, "0.11.0"},
+	{3, "0.10.1"},
+	{2, "0.10.0"},
+}
+
+// APIVersion 브로커가 지원하는 API 버전 범위
+type APIVersion struct {
+	APIKey     int    `json:"api_key"`
+	Name       string `json:"name"`
+	MinVersion int    `json:"min_version"`
+	MaxVersion int    `json:"max_version"`
+}
+
+// BrokerVersionInfo 브로커별 API 버전과 추정 Kafka 버전
+type BrokerVersionInfo struct {
+	BrokerInfo
+	// KafkaVersion API 버전으로 추정한 최소 Kafka 버전 (예: "3.7+")
+	KafkaVersion string       `json:"kafka_version"`
+	APIVersions  []APIVersion `json:"api_versions,omitempty"`
+	Error        string       `json:"error,omitempty"`
+}
+
+// describeBrokerVersions 브로커마다 ApiVersions 요청을 보내 지원 버전과 Kafka 버전 추정
+func describeBrokerVersions(brokers []kafka.Broker) []BrokerVersionInfo {
+	client := newKafkaClient()
+
+	infos := make([]BrokerVersionInfo, 0, len(brokers))
+	for _, b := range brokers {
+		info := BrokerVersionInfo{
+			BrokerInfo: BrokerInfo{ID: b.ID, Host: b.Host, Port: b.Port, Rack: b.Rack},
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		resp, err := client.ApiVersions(ctx, &kafka.ApiVersionsRequest{
+			Addr: kafka.TCP(b.Host + ":" + strconv.Itoa(b.Port)),
+		})
+		cancel()
+		if err == nil && resp.Error != nil {
+			err = resp.Error
+		}
+		if err != nil {
+			info.KafkaVersion = "unknown"
+			info.Error = err.Error()
+			infos = append(infos, info)
+			continue
+		}
+
+		for _, k := range resp.ApiKeys {
+			info.APIVersions = append(info.APIVersions, APIVersion{
+				APIKey:     k.ApiKey,
+				Name:       k.ApiName,
+				MinVersion: k.MinVersion,
+				MaxVersion: k.MaxVersion,
+			})
+		}
+		sort.Slice(info.APIVersions, func(i, j int) bool {
+			return info.APIVersions[i].APIKey < info.APIVersions[j].APIKey
+		})
+		info.KafkaVersion = inferKafkaVersion(info.APIVersions)
+
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+	return infos
+}
+
+// inferKafkaVersion Fetch API 최대 버전으로 Kafka 최소 버전 추정
+func inferKafkaVersion(versions []APIVersion) string {
+	for _, v := range versions {
+		if v.APIKey != fetchAPIKey {
+			continue
+		}
+		for _, known := range kafkaVersionsByFetch {
+			if v.MaxVersion >= known.maxVersion {
+				return known.version + "+"
+			}
+		}
+	}
+	return "unknown"
+}
+
+// inferClusterMode DescribeQuorum 지원 여부로 KRaft/ZooKeeper 모드 판별
+func inferClusterMode(brokers []BrokerVersionInfo) string {
+	known := false
+	for _, b := range brokers {
+		if b.Error != "" {
+			continue
+		}
+		known = true
+		for _, v := range b.APIVersions {
+			if v.APIKey == describeQuorumAPIKey {
+				return clusterModeKRaft
+			}
+		}
+	}
+	if !known {
+		return "unknown"
+	}
+	return clusterModeZooKeeper
+}
+
+// apiVersionsDiffer 브로커 간 지원 API 버전 범위가 다른지 여부 (롤링 업그레이드 중 확인용)
+func apiVersionsDiffer(brokers []BrokerVersionInfo) bool {
+	var base map[int]APIVersion
+	for _, b := range brokers {
+		if b.Error != "" {
+			continue
+		}
+		current := make(map[int]APIVersion, len(b.APIVersions))
+		for _, v := range b.APIVersions {
+			current[v.APIKey] = v
+		}
+		if base == nil {
+			base = current
+			continue
+		}
+		if len(current) != len(base) {
+			return true
+		}
+		for key, v := range current {
+			if base[key] != v {
+				return true
+			}
+		}
+	}
+	return false
+}
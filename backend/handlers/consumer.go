@@ -25,8 +25,8 @@ type ConsumedMessage struct {
 	Topic     string    `json:"topic"`
 	Partition int       `json:"partition"`
 	Offset    int64     `json:"offset"`
-	Key       string    `json:"key"`
-	Value     string    `json:"value"`
+	Key       *string   `json:"key"`   // null 키는 null, 빈 키는 ""
+	Value     *string   `json:"value"` // null 값(tombstone)은 null, 빈 값은 ""
 	Timestamp time.Time `json:"timestamp"`
 }
 
@@ -51,60 +51,39 @@ func ConsumeMessages(c *gin.Context) {
 		partition = p
 	}
 
-	offset := kafka.LastOffset
+	// 오프셋을 지정하지 않으면 처음부터 (-2는 처음, -1은 마지막)
+	offset := kafka.FirstOffset
 	if offsetStr != "" {
 		o, err := strconv.ParseInt(offsetStr, 10, 64)
-		if err != nil {
+		if err != nil || o < kafka.FirstOffset {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid offset"})
 			return
 		}
 		offset = o
 	}
 
-	// Reader 생성
-	reader := kafka.NewReader(kafka.ReaderConfig{
-		Brokers:   []string{kafkaBrokers},
-		Topic:     topic,
-		Partition: partition,
-		MinBytes:  10e3, // 10KB
-		MaxBytes:  10e6, // 10MB
-		MaxWait:   1 * time.Second,
-	})
-	defer reader.Close()
-
-	// 특정 오프셋으로 이동
-	if offsetStr != "" {
-		reader.SetOffset(offset)
+	isolation, err := parseIsolation(c.Query("isolation"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	// 메시지 읽기 (최대 10개)
+	// 메시지 읽기 (최대 10개, 트랜잭션 표시와 read_committed에서 중단된 트랜잭션은 제외)
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	var messages []ConsumedMessage
-	for i := 0; i < 10; i++ {
-		msg, err := reader.ReadMessage(ctx)
-		if err != nil {
-			if err == context.DeadlineExceeded {
-				break
-			}
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
-		}
-
-		messages = append(messages, ConsumedMessage{
-			Topic:     msg.Topic,
-			Partition: msg.Partition,
-			Offset:    msg.Offset,
-			Key:       string(msg.Key),
-			Value:     string(msg.Value),
-			Timestamp: msg.Time,
-		})
+	result, err := fetchPartition(ctx, topic, partition, offset, 10, isolation)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"messages": messages,
-		"count":    len(messages),
+		"messages":           result.messages,
+		"count":              len(result.messages),
+		"isolation":          isolationName(isolation),
+		"high_watermark":     result.highWatermark,
+		"last_stable_offset": result.lastStableOffset,
 	})
 }
 
@@ -122,6 +101,12 @@ func ConsumeMessagesWebSocket(c *gin.Context) {
 		group = "default-group"
 	}
 
+	// kafka-go Reader는 트랜잭션 표시와 중단된 레코드를 걸러내지 않으므로 read_committed는 HTTP 조회에서만 지원
+	if err := checkStreamingIsolation(c.Query("isolation")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	// WebSocket 업그레이드
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
@@ -144,6 +129,8 @@ func ConsumeMessagesWebSocket(c *gin.Context) {
 
 	log.Printf("WebSocket consumer started: topic=%s, group=%s", topic, group)
 
+	nulls := newNullResolver(topic)
+
 	// 연결 확인을 위한 핑 메시지 전송 고루틴
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -186,14 +173,7 @@ func ConsumeMessagesWebSocket(c *gin.Context) {
 			}
 
 			// 메시지 구조화
-			consumedMsg := ConsumedMessage{
-				Topic:     msg.Topic,
-				Partition: msg.Partition,
-				Offset:    msg.Offset,
-				Key:       string(msg.Key),
-				Value:     string(msg.Value),
-				Timestamp: msg.Time,
-			}
+			consumedMsg := nulls.consumed(msg)
 
 			// WebSocket으로 전송
 			if err := conn.WriteJSON(consumedMsg); err != nil {
@@ -229,6 +209,12 @@ func StreamMessages(c *gin.Context) {
 		group = "streaming-group"
 	}
 
+	// kafka-go Reader는 트랜잭션 표시와 중단된 레코드를 걸러내지 않으므로 read_committed는 HTTP 조회에서만 지원
+	if err := checkStreamingIsolation(c.Query("isolation")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	// SSE 헤더 설정
 	c.Writer.Header().Set("Content-Type", "text/event-stream")
 	c.Writer.Header().Set("Cache-Control", "no-cache")
@@ -247,6 +233,7 @@ func StreamMessages(c *gin.Context) {
 	defer reader.Close()
 
 	// 스트리밍 시작
+	nulls := newNullResolver(topic)
 	for {
 		msg, err := reader.ReadMessage(context.Background())
 		if err != nil {
@@ -254,14 +241,7 @@ func StreamMessages(c *gin.Context) {
 			break
 		}
 
-		consumedMsg := ConsumedMessage{
-			Topic:     msg.Topic,
-			Partition: msg.Partition,
-			Offset:    msg.Offset,
-			Key:       string(msg.Key),
-			Value:     string(msg.Value),
-			Timestamp: msg.Time,
-		}
+		consumedMsg := nulls.consumed(msg)
 
 		data, _ := json.Marshal(consumedMsg)
 		fmt.Fprintf(c.Writer, "data: %s\n\n", data)
@@ -0,0 +1,838 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"backend/auth"
+
+	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/protocol"
+)
+
+const (
+	// copyBatchSize 전송 요청 하나에 담는 최대 메시지 수
+	copyBatchSize = 500
+	// copyMaxConcurrency 동시에 복사하는 원본 파티션 수
+	copyMaxConcurrency = 8
+	// copyWriteTimeout 전송 요청 하나의 제한 시간
+	copyWriteTimeout = 30 * time.Second
+	// copyMaxRunning 동시에 실행할 수 있는 작업 수
+	copyMaxRunning = 5
+	// copyMaxFinished 조회용으로 보관하는 종료된 작업 수
+	copyMaxFinished = 50
+)
+
+// CopyRequest 토픽 간 복사 작업 요청
+type CopyRequest struct {
+	SourceTopic string `json:"sourceTopic" binding:"required"`
+	TargetTopic string `json:"targetTopic" binding:"required"`
+	// SourceCluster, TargetCluster KAFKA_CLUSTERS에 등록된 클러스터 이름 (생략 시 이 대시보드의 클러스터)
+	SourceCluster string `json:"sourceCluster"`
+	TargetCluster string `json:"targetCluster"`
+	// Partitions 복사할 원본 파티션 (생략 시 전체)
+	Partitions []int `json:"partitions"`
+	// StartOffset, EndOffset 파티션별 오프셋 범위 (EndOffset 포함, 생략 시 처음부터 작업 시작 시점의 끝까지)
+	StartOffset *int64 `json:"startOffset"`
+	EndOffset   *int64 `json:"endOffset"`
+	// StartTime, EndTime 타임스탬프 범위 (EndTime 미포함, 오프셋 범위와 함께 지정하면 둘 다 만족하는 구간)
+	StartTime *time.Time `json:"startTime"`
+	EndTime   *time.Time `json:"endTime"`
+	Filter    CopyFilter `json:"filter"`
+	// PreservePartitions 원본과 같은 번호의 파티션으로 전송 (생략 시 balancer로 분배)
+	PreservePartitions bool `json:"preservePartitions"`
+	// PreserveTimestamps 원본 타임스탬프 유지 (생략 시 timestamp 옵션 또는 전송 시각)
+	PreserveTimestamps bool `json:"preserveTimestamps"`
+	// Rate 초당 최대 메시지 수 (0이면 제한 없음)
+	Rate float64 `json:"rate"`
+	// Isolation 원본 읽기 격리 수준 (기본 read_committed로 중단된 트랜잭션은 복사하지 않음)
+	Isolation string `json:"isolation"`
+	ProducerOptions
+}
+
+// CopyFilter 복사할 메시지 조건 (모두 만족해야 복사, null 키/값은 패턴과 일치하지 않음)
+type CopyFilter struct {
+	// KeyPattern, ValuePattern 키/값 정규식
+	KeyPattern   string `json:"keyPattern"`
+	ValuePattern string `json:"valuePattern"`
+	// Headers 있어야 하는 헤더 (valuePattern을 비우면 키만 확인)
+	Headers []CopyHeaderFilter `json:"headers"`
+}
+
+// CopyHeaderFilter 헤더 조건
+type CopyHeaderFilter struct {
+	Key          string `json:"key"`
+	ValuePattern string `json:"valuePattern"`
+}
+
+// CopyStatus 복사 작업 상태
+type CopyStatus struct {
+	ID             string                `json:"id"`
+	State          string                `json:"state"` // running, completed, stopped, failed
+	StartedBy      string                `json:"started_by,omitempty"`
+	Request        CopyRequest           `json:"request"`
+	StartedAt      time.Time             `json:"started_at"`
+	EndedAt        *time.Time            `json:"ended_at,omitempty"`
+	ElapsedMs      int64                 `json:"elapsed_ms"`
+	Read           int64                 `json:"read"`
+	Copied         int64                 `json:"copied"`
+	Skipped        int64                 `json:"skipped"`
+	Failed         int64                 `json:"failed"`
+	Bytes          int64                 `json:"bytes"`
+	Progress       float64               `json:"progress"` // 범위 오프셋 기준 0~1
+	MessagesPerSec float64               `json:"messages_per_sec"`
+	Partitions     []CopyPartitionStatus `json:"partitions"`
+	Error          string                `json:"error,omitempty"`
+}
+
+// CopyPartitionStatus 원본 파티션별 진행 상황 (end_offset 미포함)
+type CopyPartitionStatus struct {
+	Partition   int    `json:"partition"`
+	StartOffset int64  `json:"start_offset"`
+	EndOffset   int64  `json:"end_offset"`
+	NextOffset  int64  `json:"next_offset"`
+	Read        int64  `json:"read"`
+	Copied      int64  `json:"copied"`
+	Done        bool   `json:"done"`
+	Error       string `json:"error,omitempty"`
+}
+
+// copyMatcher 컴파일한 필터
+type copyMatcher struct {
+	key     *regexp.Regexp
+	value   *regexp.Regexp
+	headers []copyHeaderMatcher
+}
+
+type copyHeaderMatcher struct {
+	key   string
+	value *regexp.Regexp
+}
+
+// copyJob 실행 중이거나 종료된 복사 작업
+type copyJob struct {
+	id        string
+	req       CopyRequest
+	startedBy string
+	startedAt time.Time
+	source    net.Addr
+	target    net.Addr
+	isolation kafka.IsolationLevel
+	matcher   copyMatcher
+
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	// issued 처리량 제한용으로 지금까지 배정한 메시지 수
+	issued atomic.Int64
+
+	mu         sync.Mutex
+	state      string
+	stopped    bool
+	endedAt    time.Time
+	err        string
+	skipped    int64
+	failed     int64
+	bytes      int64
+	partitions []*CopyPartitionStatus
+}
+
+// copyJobs 복사 작업 목록 (시작 순서 유지)
+var copyJobs struct {
+	mu    sync.Mutex
+	byID  map[string]*copyJob
+	order []*copyJob
+}
+
+// StartCopyJob 토픽 간 복사 작업 시작
+func StartCopyJob(c *gin.Context) {
+	var req CopyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Isolation == "" {
+		req.Isolation = "read_committed"
+	}
+
+	switch {
+	case req.Rate < 0:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "rate must not be negative"})
+		return
+	case req.StartOffset != nil && *req.StartOffset < 0, req.EndOffset != nil && *req.EndOffset < 0:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "startOffset and endOffset must not be negative"})
+		return
+	case req.StartOffset != nil && req.EndOffset != nil && *req.EndOffset < *req.StartOffset:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "endOffset must not be less than startOffset"})
+		return
+	case req.StartTime != nil && req.EndTime != nil && !req.EndTime.After(*req.StartTime):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "endTime must be after startTime"})
+		return
+	case req.Idempotent:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "idempotent is not supported for copy jobs"})
+		return
+	case req.PreserveTimestamps && req.Timestamp != nil:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "timestamp cannot be combined with preserveTimestamps"})
+		return
+	}
+
+	job := &copyJob{
+		req:   req,
+		state: "running",
+		done:  make(chan struct{}),
+	}
+	if principal := auth.PrincipalFrom(c); principal != nil {
+		job.startedBy = principal.Name
+	}
+
+	var err error
+	if job.source, err = clusterAddr(req.SourceCluster); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("sourceCluster: %v", err)})
+		return
+	}
+	if job.target, err = clusterAddr(req.TargetCluster); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("targetCluster: %v", err)})
+		return
+	}
+	if job.isolation, err = parseIsolation(req.Isolation); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if job.matcher, err = req.Filter.compile(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Writer 설정 확인 (작업자마다 실제 Writer를 만듦)
+	if _, err := req.ProducerOptions.newWriter(req.TargetTopic, nil); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	sourcePartitions, err := topicPartitionIDs(ctx, job.source, req.SourceTopic)
+	if err != nil {
+		respondCopyTopicError(c, "source", req.SourceTopic, err)
+		return
+	}
+	targetPartitions, err := topicPartitionIDs(ctx, job.target, req.TargetTopic)
+	if err != nil {
+		respondCopyTopicError(c, "target", req.TargetTopic, err)
+		return
+	}
+
+	partitions := req.Partitions
+	if len(partitions) == 0 {
+		partitions = sourcePartitions
+	}
+	seen := make(map[int]bool, len(partitions))
+	for _, p := range partitions {
+		if seen[p] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("partition %d is listed more than once", p)})
+			return
+		}
+		seen[p] = true
+		if !containsPartition(sourcePartitions, p) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("partition %d does not exist in source topic %s (%d partitions)", p, req.SourceTopic, len(sourcePartitions))})
+			return
+		}
+		if req.PreservePartitions && !containsPartition(targetPartitions, p) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("preservePartitions: partition %d does not exist in target topic %s (%d partitions)", p, req.TargetTopic, len(targetPartitions))})
+			return
+		}
+	}
+
+	ranges, err := job.resolveRanges(ctx, partitions)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to resolve offset range: %v", err)})
+		return
+	}
+	job.partitions = ranges
+
+	runCtx, runCancel := context.WithCancel(context.Background())
+	job.cancel = runCancel
+	if !registerCopyJob(job) {
+		runCancel()
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": fmt.Sprintf("Too many running copy jobs (max %d)", copyMaxRunning)})
+		return
+	}
+	go job.run(runCtx)
+
+	c.JSON(http.StatusAccepted, job.status())
+}
+
+// ListCopyJobs 복사 작업 목록 (최근 시작 순, 원본과 대상 토픽 모두 조회 권한이 있는 작업만)
+func ListCopyJobs(c *gin.Context) {
+	copyJobs.mu.Lock()
+	jobs := append([]*copyJob(nil), copyJobs.order...)
+	copyJobs.mu.Unlock()
+
+	principal := auth.PrincipalFrom(c)
+	statuses := make([]CopyStatus, 0, len(jobs))
+	for i := len(jobs) - 1; i >= 0; i-- {
+		req := jobs[i].req
+		if principal != nil && (!principal.Allowed(auth.RoleViewer, req.SourceTopic) || !principal.Allowed(auth.RoleViewer, req.TargetTopic)) {
+			continue
+		}
+		statuses = append(statuses, jobs[i].status())
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"jobs":  statuses,
+		"count": len(statuses),
+	})
+}
+
+// GetCopyJob 복사 작업 상태 조회 (Accept: text/event-stream이면 1초마다 progress 이벤트)
+func GetCopyJob(c *gin.Context) {
+	job := findCopyJob(c.Param("id"))
+	if job == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Copy job %s not found", c.Param("id"))})
+		return
+	}
+
+	if !strings.Contains(c.GetHeader("Accept"), "text/event-stream") {
+		c.JSON(http.StatusOK, job.status())
+		return
+	}
+
+	c.Writer.Header().Set("Content-Type", "text/event-stream")
+	c.Writer.Header().Set("Cache-Control", "no-cache")
+	c.Writer.Header().Set("Connection", "keep-alive")
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		c.SSEvent("progress", job.status())
+		c.Writer.Flush()
+
+		select {
+		case <-job.done:
+			c.SSEvent("done", job.status())
+			c.Writer.Flush()
+			return
+		case <-c.Request.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// StopCopyJob 복사 작업 취소 (진행 중인 전송 요청이 끝날 때까지 기다린 뒤 최종 상태 반환)
+func StopCopyJob(c *gin.Context) {
+	job := findCopyJob(c.Param("id"))
+	if job == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Copy job %s not found", c.Param("id"))})
+		return
+	}
+
+	job.mu.Lock()
+	if job.state == "running" {
+		job.stopped = true
+	}
+	job.mu.Unlock()
+	job.cancel()
+	<-job.done
+
+	c.JSON(http.StatusOK, job.status())
+}
+
+// CopyJobTopic 경로의 작업 ID로 복사 대상 토픽 조회 (토픽별 권한 검사용)
+func CopyJobTopic(param string) auth.TopicSource {
+	return func(c *gin.Context) string {
+		if job := findCopyJob(c.Param(param)); job != nil {
+			return job.req.TargetTopic
+		}
+		return ""
+	}
+}
+
+// CopyJobSourceTopic 경로의 작업 ID로 복사 원본 토픽 조회 (토픽별 권한 검사용)
+func CopyJobSourceTopic(param string) auth.TopicSource {
+	return func(c *gin.Context) string {
+		if job := findCopyJob(c.Param(param)); job != nil {
+			return job.req.SourceTopic
+		}
+		return ""
+	}
+}
+
+// registerCopyJob 작업 등록 (실행 중인 작업이 너무 많으면 false, 오래된 종료 작업 정리)
+func registerCopyJob(job *copyJob) bool {
+	copyJobs.mu.Lock()
+	defer copyJobs.mu.Unlock()
+
+	running, finished := 0, 0
+	for _, j := range copyJobs.order {
+		if j.running() {
+			running++
+		} else {
+			finished++
+		}
+	}
+	if running >= copyMaxRunning {
+		return false
+	}
+
+	kept := copyJobs.order[:0]
+	for _, j := range copyJobs.order {
+		if finished >= copyMaxFinished && !j.running() {
+			delete(copyJobs.byID, j.id)
+			finished--
+			continue
+		}
+		kept = append(kept, j)
+	}
+
+	if copyJobs.byID == nil {
+		copyJobs.byID = make(map[string]*copyJob)
+	}
+	job.id = newJobID()
+	job.startedAt = time.Now()
+	copyJobs.byID[job.id] = job
+	copyJobs.order = append(kept, job)
+	return true
+}
+
+// findCopyJob ID로 작업 조회
+func findCopyJob(id string) *copyJob {
+	copyJobs.mu.Lock()
+	defer copyJobs.mu.Unlock()
+	return copyJobs.byID[id]
+}
+
+// topicPartitionIDs 클러스터의 토픽 파티션 번호 (오름차순)
+func topicPartitionIDs(ctx context.Context, addr net.Addr, topic string) ([]int, error) {
+	client := &kafka.Client{Addr: addr, Timeout: 10 * time.Second}
+	meta, err := client.Metadata(ctx, &kafka.MetadataRequest{Topics: []string{topic}})
+	if err != nil {
+		return nil, err
+	}
+	if len(meta.Topics) == 0 {
+		return nil, kafka.UnknownTopicOrPartition
+	}
+	if meta.Topics[0].Error != nil {
+		return nil, meta.Topics[0].Error
+	}
+	ids := make([]int, len(meta.Topics[0].Partitions))
+	for i, p := range meta.Topics[0].Partitions {
+		ids[i] = p.ID
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
+
+// respondCopyTopicError 원본/대상 토픽 조회 실패 응답 (토픽이 없으면 404)
+func respondCopyTopicError(c *gin.Context, role, topic string, err error) {
+	if errors.Is(err, kafka.UnknownTopicOrPartition) {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("%s topic %s not found", role, topic)})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to read %s topic %s: %v", role, topic, err)})
+}
+
+// compile 필터 정규식 컴파일
+func (f CopyFilter) compile() (copyMatcher, error) {
+	var m copyMatcher
+	var err error
+	if f.KeyPattern != "" {
+		if m.key, err = regexp.Compile(f.KeyPattern); err != nil {
+			return m, fmt.Errorf("invalid filter.keyPattern: %v", err)
+		}
+	}
+	if f.ValuePattern != "" {
+		if m.value, err = regexp.Compile(f.ValuePattern); err != nil {
+			return m, fmt.Errorf("invalid filter.valuePattern: %v", err)
+		}
+	}
+	for i, h := range f.Headers {
+		if h.Key == "" {
+			return m, fmt.Errorf("filter.headers[%d]: key is required", i)
+		}
+		hm := copyHeaderMatcher{key: h.Key}
+		if h.ValuePattern != "" {
+			if hm.value, err = regexp.Compile(h.ValuePattern); err != nil {
+				return m, fmt.Errorf("invalid filter.headers[%d].valuePattern: %v", i, err)
+			}
+		}
+		m.headers = append(m.headers, hm)
+	}
+	return m, nil
+}
+
+// match 메시지가 필터를 모두 만족하는지 확인
+func (m copyMatcher) match(msg kafka.Message) bool {
+	if m.key != nil && (msg.Key == nil || !m.key.Match(msg.Key)) {
+		return false
+	}
+	if m.value != nil && (msg.Value == nil || !m.value.Match(msg.Value)) {
+		return false
+	}
+	for _, hm := range m.headers {
+		found := false
+		for _, h := range msg.Headers {
+			if h.Key == hm.key && (hm.value == nil || hm.value.Match(h.Value)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveRanges 요청의 오프셋/시각 범위를 파티션별 [시작, 끝) 오프셋으로 변환 (끝은 작업 시작 시점 이하)
+func (j *copyJob) resolveRanges(ctx context.Context, partitions []int) ([]*CopyPartitionStatus, error) {
+	bounds := offsetBounds{StartOffset: j.req.StartOffset, EndOffset: j.req.EndOffset, StartTime: j.req.StartTime, EndTime: j.req.EndTime}
+	resolved, err := resolveOffsetRanges(ctx, j.source, j.req.SourceTopic, partitions, j.isolation, bounds)
+	if err != nil {
+		return nil, err
+	}
+	ranges := make([]*CopyPartitionStatus, len(resolved))
+	for i, r := range resolved {
+		ranges[i] = &CopyPartitionStatus{Partition: r.partition, StartOffset: r.start, EndOffset: r.end, NextOffset: r.start, Done: r.start >= r.end}
+	}
+	return ranges, nil
+}
+
+// offsetBounds 오프셋/시각 범위 조건 (EndOffset 포함, EndTime 미포함, 둘 다 지정하면 둘 다 만족하는 구간)
+type offsetBounds struct {
+	StartOffset *int64
+	EndOffset   *int64
+	StartTime   *time.Time
+	EndTime     *time.Time
+}
+
+// offsetRange 파티션의 [start, end) 오프셋 구간
+type offsetRange struct {
+	partition  int
+	start, end int64
+}
+
+// resolveOffsetRanges 범위 조건을 파티션별 [시작, 끝) 오프셋으로 변환 (끝은 호출 시점의 마지막 오프셋 이하)
+func resolveOffsetRanges(ctx context.Context, addr net.Addr, topic string, partitions []int, isolation kafka.IsolationLevel, bounds offsetBounds) ([]offsetRange, error) {
+	client := &kafka.Client{Addr: addr, Timeout: 10 * time.Second}
+	list := func(timestamp int64) (map[int]kafka.PartitionOffsets, error) {
+		requests := make([]kafka.OffsetRequest, len(partitions))
+		for i, p := range partitions {
+			requests[i] = kafka.OffsetRequest{Partition: p, Timestamp: timestamp}
+		}
+		resp, err := client.ListOffsets(ctx, &kafka.ListOffsetsRequest{
+			Topics:         map[string][]kafka.OffsetRequest{topic: requests},
+			IsolationLevel: isolation,
+		})
+		if err != nil {
+			return nil, err
+		}
+		offsets := make(map[int]kafka.PartitionOffsets, len(partitions))
+		for _, p := range resp.Topics[topic] {
+			if p.Error != nil {
+				return nil, fmt.Errorf("partition %d: %w", p.Partition, p.Error)
+			}
+			offsets[p.Partition] = p
+		}
+		if len(offsets) != len(partitions) {
+			return nil, errors.New("incomplete ListOffsets response")
+		}
+		return offsets, nil
+	}
+	// 시각 기준 오프셋 (그 이후 메시지가 없으면 -1)
+	timeOffset := func(offsets kafka.PartitionOffsets) int64 {
+		for o := range offsets.Offsets {
+			return o
+		}
+		return -1
+	}
+
+	first, err := list(kafka.FirstOffset)
+	if err != nil {
+		return nil, err
+	}
+	last, err := list(kafka.LastOffset)
+	if err != nil {
+		return nil, err
+	}
+	var fromTime, toTime map[int]kafka.PartitionOffsets
+	if bounds.StartTime != nil {
+		if fromTime, err = list(bounds.StartTime.UnixMilli()); err != nil {
+			return nil, err
+		}
+	}
+	if bounds.EndTime != nil {
+		if toTime, err = list(bounds.EndTime.UnixMilli()); err != nil {
+			return nil, err
+		}
+	}
+
+	ranges := make([]offsetRange, len(partitions))
+	for i, p := range partitions {
+		start, end := first[p].FirstOffset, last[p].LastOffset
+		if bounds.StartOffset != nil {
+			start = max(start, *bounds.StartOffset)
+		}
+		if bounds.EndOffset != nil {
+			end = min(end, *bounds.EndOffset+1)
+		}
+		if fromTime != nil {
+			if o := timeOffset(fromTime[p]); o >= 0 {
+				start = max(start, o)
+			} else {
+				start = end // 시작 시각 이후 메시지 없음
+			}
+		}
+		if toTime != nil {
+			if o := timeOffset(toTime[p]); o >= 0 {
+				end = min(end, o)
+			}
+		}
+		ranges[i] = offsetRange{partition: p, start: start, end: max(start, end)}
+	}
+	return ranges, nil
+}
+
+// run 원본 파티션을 작업자들이 나눠 복사하고 모두 끝나면 최종 상태 기록
+func (j *copyJob) run(ctx context.Context) {
+	defer close(j.done)
+	defer j.cancel()
+
+	queue := make(chan *CopyPartitionStatus, len(j.partitions))
+	for _, p := range j.partitions {
+		if !p.Done {
+			queue <- p
+		}
+	}
+	close(queue)
+
+	var wg sync.WaitGroup
+	for i := 0; i < min(len(queue), copyMaxConcurrency); i++ {
+		fixed := make(map[int]int)
+		if !j.req.PreservePartitions {
+			fixed = nil
+		}
+		w, err := j.req.ProducerOptions.newWriter(j.req.TargetTopic, fixed)
+		if err != nil {
+			j.fail(nil, err)
+			break
+		}
+		w.Addr = j.target
+		w.BatchSize = copyBatchSize
+		w.BatchTimeout = time.Millisecond
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer w.Close()
+			for p := range queue {
+				if ctx.Err() != nil {
+					return
+				}
+				if err := j.copyPartition(ctx, p, w, fixed); err != nil {
+					j.fail(p, err)
+					j.cancel() // 순서가 어긋나지 않도록 오류가 나면 전체 작업 중단
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.endedAt = time.Now()
+	switch {
+	case j.err != "":
+		j.state = "failed"
+	case j.stopped:
+		j.state = "stopped"
+	default:
+		j.state = "completed"
+	}
+}
+
+// copyPartition 원본 파티션 하나의 범위를 끝까지 복사
+func (j *copyJob) copyPartition(ctx context.Context, p *CopyPartitionStatus, w *kafka.Writer, fixed map[int]int) error {
+	offset := p.NextOffset
+	for offset < p.EndOffset {
+		var messages []kafka.Message
+		var read, size int64
+		fetched, err := fetchRecords(ctx, j.source, j.req.SourceTopic, p.Partition, offset, j.isolation, func(rec *protocol.Record) (bool, error) {
+			if rec.Offset >= p.EndOffset || len(messages) >= copyBatchSize {
+				return false, nil
+			}
+			msg, err := j.message(rec)
+			if err != nil {
+				return false, err
+			}
+			read++
+			if j.matcher.match(msg) {
+				messages = append(messages, msg)
+				size += int64(len(msg.Key) + len(msg.Value))
+			}
+			return true, nil
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil // 취소
+			}
+			return fmt.Errorf("read offset %d: %w", offset, err)
+		}
+
+		if len(messages) > 0 {
+			if err := j.pace(ctx, len(messages)); err != nil {
+				return nil // 취소
+			}
+			for i := range messages {
+				messages[i].WriterData = i
+				if fixed != nil {
+					fixed[i] = p.Partition
+				}
+			}
+			writeCtx, cancel := context.WithTimeout(context.Background(), copyWriteTimeout)
+			err := w.WriteMessages(writeCtx, messages...)
+			cancel()
+			if err != nil {
+				j.mu.Lock()
+				j.failed += int64(len(messages))
+				j.mu.Unlock()
+				return fmt.Errorf("write messages from offset %d: %w", offset, err)
+			}
+		}
+
+		j.mu.Lock()
+		p.Read += read
+		p.Copied += int64(len(messages))
+		p.NextOffset = min(fetched.next, p.EndOffset)
+		j.skipped += read - int64(len(messages))
+		j.bytes += size
+		j.mu.Unlock()
+
+		if fetched.next == offset && ctx.Err() != nil {
+			return nil
+		}
+		offset = fetched.next
+	}
+
+	j.mu.Lock()
+	p.Done = true
+	j.mu.Unlock()
+	return nil
+}
+
+// message 원본 레코드를 전송할 메시지로 변환 (null 키/값과 헤더 유지)
+func (j *copyJob) message(rec *protocol.Record) (kafka.Message, error) {
+	msg := kafka.Message{Headers: rec.Headers, Time: j.req.messageTime()}
+	if j.req.PreserveTimestamps {
+		msg.Time = rec.Time
+	}
+	var err error
+	if msg.Key, err = recordBytes(rec.Key); err != nil {
+		return msg, err
+	}
+	if msg.Value, err = recordBytes(rec.Value); err != nil {
+		return msg, err
+	}
+	return msg, nil
+}
+
+// recordBytes 레코드 키/값 바이트 (null은 nil, 빈 값은 길이 0인 슬라이스)
+func recordBytes(b protocol.Bytes) ([]byte, error) {
+	if b == nil {
+		return nil, nil
+	}
+	data, err := protocol.ReadAll(b)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{}, data...), nil
+}
+
+// pace 처리량 제한에 맞춰 n개 메시지를 보낼 시각까지 대기 (ctx가 끝나면 오류)
+func (j *copyJob) pace(ctx context.Context, n int) error {
+	if j.req.Rate <= 0 {
+		return nil
+	}
+	first := j.issued.Add(int64(n)) - int64(n)
+	due := j.startedAt.Add(time.Duration(float64(first) / j.req.Rate * float64(time.Second)))
+	if d := time.Until(due); d > 0 {
+		return sleepContext(ctx, d)
+	}
+	return ctx.Err()
+}
+
+// fail 첫 오류 기록 (p가 있으면 해당 파티션에도 기록)
+func (j *copyJob) fail(p *CopyPartitionStatus, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if p != nil {
+		p.Error = err.Error()
+	}
+	if j.err == "" {
+		j.err = err.Error()
+		if p != nil {
+			j.err = fmt.Sprintf("partition %d: %v", p.Partition, err)
+		}
+	}
+}
+
+// running 실행 중 여부
+func (j *copyJob) running() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.state == "running"
+}
+
+// status 현재 상태 스냅샷
+func (j *copyJob) status() CopyStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	end := time.Now()
+	s := CopyStatus{
+		ID:         j.id,
+		State:      j.state,
+		StartedBy:  j.startedBy,
+		Request:    j.req,
+		StartedAt:  j.startedAt,
+		Skipped:    j.skipped,
+		Failed:     j.failed,
+		Bytes:      j.bytes,
+		Partitions: make([]CopyPartitionStatus, len(j.partitions)),
+		Error:      j.err,
+	}
+	if !j.endedAt.IsZero() {
+		end = j.endedAt
+		endedAt := j.endedAt
+		s.EndedAt = &endedAt
+	}
+	s.ElapsedMs = end.Sub(j.startedAt).Milliseconds()
+
+	var total, done int64
+	for i, p := range j.partitions {
+		s.Partitions[i] = *p
+		s.Read += p.Read
+		s.Copied += p.Copied
+		total += p.EndOffset - p.StartOffset
+		done += p.NextOffset - p.StartOffset
+	}
+	s.Progress = 1
+	if total > 0 {
+		s.Progress = float64(done) / float64(total)
+	}
+	if secs := end.Sub(j.startedAt).Seconds(); secs > 0 {
+		s.MessagesPerSec = float64(s.Copied) / secs
+	}
+	return s
+}
@@ -0,0 +1,1075 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"backend/auth"
+
+	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/protocol"
+)
+
+const (
+	// dlqTopicSuffix DLQ 토픽 이름 접미사
+	dlqTopicSuffix = ".DLQ"
+	// dlqDefaultLimit, dlqMaxLimit 조회할 최근 메시지 수
+	dlqDefaultLimit = 200
+	dlqMaxLimit     = 5000
+	// dlqMaxSelection 한 번에 재전송/건너뛰기할 수 있는 메시지 수
+	dlqMaxSelection = 500
+	// dlqRedriveHeader 재전송한 메시지에 붙이는 DLQ 위치 헤더 (topic/partition/offset)
+	dlqRedriveHeader = "dlq-redriven-from"
+
+	// dlqActionRedrive, dlqActionSkip 메시지별 감사 기록 action (처리 상태도 여기서 읽음)
+	dlqActionRedrive = "dlq.message.redrive"
+	dlqActionSkip    = "dlq.message.skip"
+)
+
+// dlqErrorHeaders 오류 헤더 후보 (errorHeader를 지정하지 않으면 앞에서부터 처음 있는 헤더)
+var dlqErrorHeaders = []string{
+	"error",
+	"x-error",
+	"dlq-error",
+	"kafka_dlt-exception-fqcn",              // Spring Kafka
+	"__connect.errors.exception.class.name", // Kafka Connect
+	"kafka_dlt-exception-message",
+	"__connect.errors.exception.message",
+}
+
+// dlqOriginalTopicHeaders 원본 토픽 헤더 후보 (없으면 DLQ 토픽 이름에서 접미사를 뗀 토픽)
+var dlqOriginalTopicHeaders = []string{
+	"original-topic",
+	"x-original-topic",
+	"dlq-original-topic",
+	"kafka_dlt-original-topic",
+	"__connect.errors.topic",
+}
+
+// dlqHeaderPrefixes 재전송 시 제거하는 DLQ 헤더 접두사
+var dlqHeaderPrefixes = []string{"kafka_dlt-", "__connect.errors."}
+
+// errDLQMessageNotFound 지정한 오프셋에 커밋된 레코드가 없음
+var errDLQMessageNotFound = errors.New("message not found (deleted by retention, aborted or not committed yet)")
+
+// DLQTopic DLQ 토픽 요약
+type DLQTopic struct {
+	Topic string `json:"topic"`
+	// OriginalTopic 이름 기준 원본 토픽 (메시지에 원본 토픽 헤더가 있으면 그쪽이 우선)
+	OriginalTopic string `json:"original_topic"`
+	Partitions    int    `json:"partitions"`
+	// Messages 보존 중인 오프셋 수 (트랜잭션 표시 레코드 포함, 근사치)
+	Messages int64 `json:"messages"`
+}
+
+// DLQMessage DLQ 메시지와 처리 상태
+type DLQMessage struct {
+	ConsumedMessage
+	Headers       []MessageHeader `json:"headers"`
+	Error         string          `json:"error"` // 오류 헤더 값 (없으면 "")
+	OriginalTopic string          `json:"original_topic"`
+	Status        string          `json:"status"` // pending, redriven, skipped
+	LastAction    *DLQAction      `json:"last_action,omitempty"`
+}
+
+// DLQAction 감사 기록에서 읽은 메시지 처리 이력
+type DLQAction struct {
+	Action          string    `json:"action"` // redrive, skip
+	Actor           string    `json:"actor"`
+	Time            time.Time `json:"time"`
+	Reason          string    `json:"reason,omitempty"`
+	TargetTopic     string    `json:"target_topic,omitempty"`
+	TargetPartition *int      `json:"target_partition,omitempty"`
+	TargetOffset    *int64    `json:"target_offset,omitempty"`
+	Edited          bool      `json:"edited,omitempty"`
+}
+
+// DLQGroup 오류와 원본 토픽이 같은 메시지 묶음
+type DLQGroup struct {
+	Error          string    `json:"error"`
+	OriginalTopic  string    `json:"original_topic"`
+	Count          int       `json:"count"`
+	Pending        int       `json:"pending"`
+	Redriven       int       `json:"redriven"`
+	Skipped        int       `json:"skipped"`
+	FirstTimestamp time.Time `json:"first_timestamp"`
+	LastTimestamp  time.Time `json:"last_timestamp"`
+}
+
+// DLQMessageRef 선택한 DLQ 메시지 위치
+type DLQMessageRef struct {
+	Partition int   `json:"partition"`
+	Offset    int64 `json:"offset"`
+}
+
+// DLQRedriveMessage 재전송할 메시지와 수정 내용 (생략한 필드는 원본 유지)
+type DLQRedriveMessage struct {
+	DLQMessageRef
+	Key       *string `json:"key"`
+	NullKey   bool    `json:"nullKey"`
+	Value     *string `json:"value"`
+	NullValue bool    `json:"nullValue"`
+	// Headers 지정하면 원본 헤더 대신 전송 (재전송 위치 헤더는 항상 추가)
+	Headers *[]MessageHeader `json:"headers"`
+}
+
+// DLQRedriveRequest DLQ 메시지 재전송 요청
+type DLQRedriveRequest struct {
+	Messages []DLQRedriveMessage `json:"messages" binding:"required"`
+	// TargetTopic 모든 메시지를 보낼 토픽 (생략 시 메시지별 원본 토픽)
+	TargetTopic string `json:"targetTopic"`
+	// OriginalTopicHeader 원본 토픽 헤더 이름 (생략 시 알려진 헤더 후보)
+	OriginalTopicHeader string `json:"originalTopicHeader"`
+	// KeepHeaders true면 DLQ 오류 헤더도 제거하지 않고 전송
+	KeepHeaders bool `json:"keepHeaders"`
+	// Force true면 이미 재전송했거나 건너뛴 메시지도 재전송
+	Force  bool   `json:"force"`
+	Reason string `json:"reason"`
+	ProducerOptions
+}
+
+// DLQSkipRequest DLQ 메시지 건너뛰기 요청
+type DLQSkipRequest struct {
+	Messages []DLQMessageRef `json:"messages" binding:"required"`
+	// Force true면 이미 재전송했거나 건너뛴 메시지도 다시 건너뜀으로 기록
+	Force  bool   `json:"force"`
+	Reason string `json:"reason"`
+}
+
+// DLQResult 메시지별 처리 결과
+type DLQResult struct {
+	Partition       int    `json:"partition"`
+	Offset          int64  `json:"offset"`
+	TargetTopic     string `json:"target_topic,omitempty"`
+	TargetPartition *int   `json:"target_partition,omitempty"`
+	TargetOffset    *int64 `json:"target_offset,omitempty"`
+	Error           string `json:"error,omitempty"`
+}
+
+// dlqHeaderNames 오류/원본 토픽 헤더 이름 (비어 있으면 알려진 헤더 후보에서 찾음)
+type dlqHeaderNames struct {
+	errorHeader         string
+	originalTopicHeader string
+}
+
+// ListDLQTopics DLQ 토픽 목록 (이름이 .DLQ로 끝나는 토픽 중 조회 권한이 있는 토픽)
+func ListDLQTopics(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client := newKafkaClient()
+	meta, err := client.Metadata(ctx, &kafka.MetadataRequest{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to get metadata: %v", err)})
+		return
+	}
+
+	principal := auth.PrincipalFrom(c)
+	requests := make(map[string][]kafka.OffsetRequest)
+	for _, t := range meta.Topics {
+		if t.Error != nil || !isDLQTopic(t.Name) {
+			continue
+		}
+		if principal != nil && !principal.Allowed(auth.RoleViewer, t.Name) {
+			continue
+		}
+		for _, p := range t.Partitions {
+			requests[t.Name] = append(requests[t.Name], kafka.FirstOffsetOf(p.ID), kafka.LastOffsetOf(p.ID))
+		}
+	}
+
+	topics := make([]DLQTopic, 0, len(requests))
+	if len(requests) > 0 {
+		resp, err := client.ListOffsets(ctx, &kafka.ListOffsetsRequest{Topics: requests, IsolationLevel: kafka.ReadCommitted})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to list offsets: %v", err)})
+			return
+		}
+		for name, partitions := range resp.Topics {
+			topic := DLQTopic{Topic: name, OriginalTopic: strings.TrimSuffix(name, dlqTopicSuffix), Partitions: len(partitions)}
+			for _, p := range partitions {
+				if p.Error == nil && p.LastOffset > p.FirstOffset {
+					topic.Messages += p.LastOffset - p.FirstOffset
+				}
+			}
+			topics = append(topics, topic)
+		}
+	}
+	sort.Slice(topics, func(i, j int) bool { return topics[i].Topic < topics[j].Topic })
+
+	c.JSON(http.StatusOK, gin.H{
+		"topics": topics,
+		"count":  len(topics),
+	})
+}
+
+// BrowseDLQ DLQ 토픽의 최근 메시지를 오류/원본 토픽별로 묶어 조회
+//
+// 파티션마다 마지막 limit개(커밋된 레코드)까지 읽어 최신순으로 limit개를 돌려준다.
+// groups는 읽은 메시지 전체 기준이고, messages에만 error, originalTopic, status 필터를 적용한다.
+func BrowseDLQ(c *gin.Context) {
+	topic := c.Param("topic")
+	if !isDLQTopic(topic) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Topic %s is not a DLQ topic (expected %s suffix)", topic, dlqTopicSuffix)})
+		return
+	}
+
+	limit := dlqDefaultLimit
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > dlqMaxLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("limit must be between 1 and %d", dlqMaxLimit)})
+			return
+		}
+		limit = n
+	}
+	status := c.Query("status")
+	switch status {
+	case "", "pending", "redriven", "skipped":
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be pending, redriven or skipped"})
+		return
+	}
+	names := dlqHeaderNames{errorHeader: c.Query("errorHeader"), originalTopicHeader: c.Query("originalTopicHeader")}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	partitions, err := topicPartitionIDs(ctx, kafka.TCP(kafkaBrokers), topic)
+	if err != nil {
+		respondDLQTopicError(c, topic, err)
+		return
+	}
+	requests := make([]kafka.OffsetRequest, 0, 2*len(partitions))
+	for _, p := range partitions {
+		requests = append(requests, kafka.FirstOffsetOf(p), kafka.LastOffsetOf(p))
+	}
+	resp, err := newKafkaClient().ListOffsets(ctx, &kafka.ListOffsetsRequest{
+		Topics:         map[string][]kafka.OffsetRequest{topic: requests},
+		IsolationLevel: kafka.ReadCommitted,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to list offsets: %v", err)})
+		return
+	}
+
+	truncated := false
+	messages := []DLQMessage{}
+	for _, p := range resp.Topics[topic] {
+		if p.Error != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to list offsets for partition %d: %v", p.Partition, p.Error)})
+			return
+		}
+		start := max(p.FirstOffset, p.LastOffset-int64(limit))
+		if start > p.FirstOffset {
+			truncated = true
+		}
+		read, err := readDLQRange(ctx, topic, p.Partition, start, p.LastOffset, names)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to read partition %d: %v", p.Partition, err)})
+			return
+		}
+		messages = append(messages, read...)
+	}
+
+	sort.Slice(messages, func(i, j int) bool {
+		a, b := messages[i], messages[j]
+		if !a.Timestamp.Equal(b.Timestamp) {
+			return a.Timestamp.After(b.Timestamp)
+		}
+		if a.Partition != b.Partition {
+			return a.Partition < b.Partition
+		}
+		return a.Offset > b.Offset
+	})
+	if len(messages) > limit {
+		messages, truncated = messages[:limit], true
+	}
+
+	actions := dlqActions(topic)
+	for i := range messages {
+		messages[i].applyActions(actions[DLQMessageRef{Partition: messages[i].Partition, Offset: messages[i].Offset}])
+	}
+
+	groups := groupDLQMessages(messages)
+
+	filtered := messages[:0:0]
+	for _, m := range messages {
+		if v, ok := c.GetQuery("error"); ok && m.Error != v {
+			continue
+		}
+		if v := c.Query("originalTopic"); v != "" && m.OriginalTopic != v {
+			continue
+		}
+		if status != "" && m.Status != status {
+			continue
+		}
+		filtered = append(filtered, m)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"topic":     topic,
+		"scanned":   len(messages),
+		"truncated": truncated,
+		"groups":    groups,
+		"messages":  filtered,
+		"count":     len(filtered),
+	})
+}
+
+// GetDLQMessage DLQ 메시지 하나와 처리 이력 조회
+func GetDLQMessage(c *gin.Context) {
+	topic := c.Param("topic")
+	if !isDLQTopic(topic) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Topic %s is not a DLQ topic (expected %s suffix)", topic, dlqTopicSuffix)})
+		return
+	}
+	partition, err := strconv.Atoi(c.Param("partition"))
+	if err != nil || partition < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid partition"})
+		return
+	}
+	offset, err := strconv.ParseInt(c.Param("offset"), 10, 64)
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset"})
+		return
+	}
+	ref := DLQMessageRef{Partition: partition, Offset: offset}
+	names := dlqHeaderNames{errorHeader: c.Query("errorHeader"), originalTopicHeader: c.Query("originalTopicHeader")}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	m, _, err := fetchDLQMessage(ctx, topic, ref, names)
+	if err != nil {
+		if errors.Is(err, errDLQMessageNotFound) || errors.Is(err, kafka.UnknownTopicOrPartition) {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Message %s[%d]@%d not found: %v", topic, partition, offset, err)})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to read message: %v", err)})
+		return
+	}
+
+	actions := dlqActions(topic)
+	history := actions[ref]
+	m.applyActions(history)
+	if history == nil {
+		history = []DLQAction{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": m,
+		"history": history,
+	})
+}
+
+// RedriveDLQMessages 선택한 DLQ 메시지를 원본 토픽(또는 targetTopic)으로 재전송
+//
+// 메시지는 DLQ에서 다시 읽어 요청의 수정 내용을 적용한 뒤, DLQ 오류 헤더를 빼고
+// 재전송 위치 헤더를 붙여 보낸다. 대상 토픽마다 producer 권한이 있어야 하며,
+// 하나라도 없으면 아무것도 보내지 않는다. 메시지마다 감사 기록을 남긴다.
+func RedriveDLQMessages(c *gin.Context) {
+	topic := c.Param("topic")
+	if !isDLQTopic(topic) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Topic %s is not a DLQ topic (expected %s suffix)", topic, dlqTopicSuffix)})
+		return
+	}
+
+	var req DLQRedriveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	refs := make([]DLQMessageRef, len(req.Messages))
+	for i, m := range req.Messages {
+		refs[i] = m.DLQMessageRef
+		if err := m.validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("messages[%d]: %v", i, err)})
+			return
+		}
+	}
+	if err := validateDLQSelection(refs); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.TargetTopic == topic {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "targetTopic must not be the DLQ topic itself"})
+		return
+	}
+
+	// 이력 확인부터 색인 갱신까지 잠가 동시 요청이 같은 메시지를 두 번 처리하지 않도록 함
+	defer lockDLQTopic(topic)()
+	actions := dlqActions(topic)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// DLQ에서 원본 메시지를 읽어 대상 토픽별로 모음
+	names := dlqHeaderNames{originalTopicHeader: req.OriginalTopicHeader}
+	results := make([]DLQResult, len(req.Messages))
+	edited := make([]bool, len(req.Messages))
+	pending := make(map[string][]int) // 대상 토픽 -> 요청 인덱스
+	outgoing := make([]kafka.Message, len(req.Messages))
+	var targets []string
+	for i, rm := range req.Messages {
+		results[i] = DLQResult{Partition: rm.Partition, Offset: rm.Offset}
+		if err := checkDLQAction(actions[rm.DLQMessageRef], req.Force); err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		m, raw, err := fetchDLQMessage(ctx, topic, rm.DLQMessageRef, names)
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+
+		target := req.TargetTopic
+		if target == "" {
+			target = m.OriginalTopic
+		}
+		switch {
+		case target == "":
+			results[i].Error = "original topic is unknown (set targetTopic)"
+			continue
+		case target == topic:
+			results[i].Error = "original topic header points at the DLQ topic itself (set targetTopic)"
+			continue
+		}
+		results[i].TargetTopic = target
+
+		outgoing[i], edited[i] = rm.message(topic, raw, names, req.KeepHeaders, req.messageTime())
+		if pending[target] == nil {
+			targets = append(targets, target)
+		}
+		pending[target] = append(pending[target], i)
+	}
+
+	// 보내기 전에 모든 대상 토픽의 권한 확인
+	if principal := auth.PrincipalFrom(c); principal != nil {
+		for _, target := range targets {
+			if !principal.Allowed(auth.RoleProducer, target) {
+				c.JSON(http.StatusForbidden, gin.H{
+					"error": fmt.Sprintf("Forbidden: %s requires role %s on topic %s", principal.Name, auth.RoleProducer, target),
+				})
+				return
+			}
+		}
+	}
+
+	writers := make(map[string]*kafka.Writer, len(targets))
+	defer func() {
+		for _, w := range writers {
+			w.Close()
+		}
+	}()
+	for _, target := range targets {
+		w, err := req.ProducerOptions.newWriter(target, nil)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		writers[target] = w
+	}
+
+	for _, target := range targets {
+		indexes := pending[target]
+		messages := make([]kafka.Message, len(indexes))
+		for j, i := range indexes {
+			messages[j] = outgoing[i]
+		}
+
+		var written []ProduceResult
+		if req.Idempotent {
+			written = writeIdempotent(ctx, writers[target], messages)
+		} else {
+			written = writeMessages(ctx, writers[target], messages)
+		}
+		for j, i := range indexes {
+			results[i].TargetPartition, results[i].TargetOffset, results[i].Error = written[j].Partition, written[j].Offset, written[j].Error
+		}
+	}
+
+	// 메시지별 감사 기록 (DLQ 목록의 처리 상태도 이 기록에서 읽음)
+	for i, r := range results {
+		params := map[string]interface{}{"edited": edited[i]}
+		if r.TargetTopic != "" {
+			params["target_topic"] = r.TargetTopic
+		}
+		if r.TargetPartition != nil {
+			params["target_partition"] = *r.TargetPartition
+		}
+		if r.TargetOffset != nil {
+			params["target_offset"] = *r.TargetOffset
+		}
+		recordDLQAction(c, dlqActionRedrive, topic, req.Messages[i].DLQMessageRef, req.Reason, params, r.Error)
+	}
+
+	respondDLQResults(c, topic, "redrive", results)
+}
+
+// SkipDLQMessages 선택한 DLQ 메시지를 재전송하지 않기로 기록
+func SkipDLQMessages(c *gin.Context) {
+	topic := c.Param("topic")
+	if !isDLQTopic(topic) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Topic %s is not a DLQ topic (expected %s suffix)", topic, dlqTopicSuffix)})
+		return
+	}
+
+	var req DLQSkipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := validateDLQSelection(req.Messages); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// 이력 확인부터 색인 갱신까지 잠가 동시 요청이 같은 메시지를 두 번 처리하지 않도록 함
+	defer lockDLQTopic(topic)()
+	actions := dlqActions(topic)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	results := make([]DLQResult, len(req.Messages))
+	for i, ref := range req.Messages {
+		results[i] = DLQResult{Partition: ref.Partition, Offset: ref.Offset}
+		if err := checkDLQAction(actions[ref], req.Force); err != nil {
+			results[i].Error = err.Error()
+		} else if _, _, err := fetchDLQMessage(ctx, topic, ref, dlqHeaderNames{}); err != nil {
+			results[i].Error = err.Error()
+		}
+		recordDLQAction(c, dlqActionSkip, topic, ref, req.Reason, map[string]interface{}{}, results[i].Error)
+	}
+
+	respondDLQResults(c, topic, "skip", results)
+}
+
+// respondDLQResults 메시지별 결과 응답 (전부 실패 500, 일부 실패 207)
+func respondDLQResults(c *gin.Context, topic, operation string, results []DLQResult) {
+	failed := 0
+	var firstErr string
+	for _, r := range results {
+		if r.Error != "" {
+			if failed == 0 {
+				firstErr = r.Error
+			}
+			failed++
+		}
+	}
+
+	status, code := "success", http.StatusOK
+	switch {
+	case failed == len(results):
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   fmt.Sprintf("Failed to %s DLQ messages: %s", operation, firstErr),
+			"results": results,
+		})
+		return
+	case failed > 0:
+		status, code = "partial", http.StatusMultiStatus
+	}
+
+	c.JSON(code, gin.H{
+		"status":        status,
+		"topic":         topic,
+		"message_count": len(results),
+		"succeeded":     len(results) - failed,
+		"failed":        failed,
+		"results":       results,
+	})
+}
+
+// isDLQTopic DLQ 토픽 이름 여부
+func isDLQTopic(topic string) bool {
+	return strings.HasSuffix(topic, dlqTopicSuffix) && len(topic) > len(dlqTopicSuffix)
+}
+
+// respondDLQTopicError DLQ 토픽 조회 실패 응답 (토픽이 없으면 404)
+func respondDLQTopicError(c *gin.Context, topic string, err error) {
+	if errors.Is(err, kafka.UnknownTopicOrPartition) {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Topic %s not found", topic)})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to get metadata: %v", err)})
+}
+
+// validateDLQSelection 선택한 메시지 수와 중복 확인
+func validateDLQSelection(refs []DLQMessageRef) error {
+	if len(refs) == 0 || len(refs) > dlqMaxSelection {
+		return fmt.Errorf("messages must contain between 1 and %d entries", dlqMaxSelection)
+	}
+	seen := make(map[DLQMessageRef]bool, len(refs))
+	for _, ref := range refs {
+		if ref.Partition < 0 || ref.Offset < 0 {
+			return fmt.Errorf("invalid message position %d@%d", ref.Partition, ref.Offset)
+		}
+		if seen[ref] {
+			return fmt.Errorf("message %d@%d is listed more than once", ref.Partition, ref.Offset)
+		}
+		seen[ref] = true
+	}
+	return nil
+}
+
+// checkDLQAction 이미 처리한 메시지면 오류 (force면 허용)
+func checkDLQAction(history []DLQAction, force bool) error {
+	if force || len(history) == 0 {
+		return nil
+	}
+	last := history[len(history)-1]
+	verb := "redriven"
+	if last.Action == "skip" {
+		verb = "skipped"
+	}
+	return fmt.Errorf("already %s by %s at %s (set force to override)", verb, last.Actor, last.Time.Format(time.RFC3339))
+}
+
+// readDLQRange 파티션의 [start, end) 구간에서 커밋된 레코드 조회
+func readDLQRange(ctx context.Context, topic string, partition int, start, end int64, names dlqHeaderNames) ([]DLQMessage, error) {
+	var messages []DLQMessage
+	for offset := start; offset < end; {
+		fetched, err := fetchRecords(ctx, kafka.TCP(kafkaBrokers), topic, partition, offset, kafka.ReadCommitted, func(rec *protocol.Record) (bool, error) {
+			if rec.Offset >= end {
+				return false, nil
+			}
+			m, _, err := dlqRecord(topic, partition, rec, names)
+			if err != nil {
+				return false, err
+			}
+			messages = append(messages, m)
+			return true, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if fetched.next == offset {
+			break // 더 읽을 레코드 없음
+		}
+		offset = fetched.next
+	}
+	return messages, nil
+}
+
+// fetchDLQMessage 지정한 오프셋의 커밋된 레코드 하나 조회 (DLQ 응답 구조와 재전송용 원본 메시지)
+func fetchDLQMessage(ctx context.Context, topic string, ref DLQMessageRef, names dlqHeaderNames) (DLQMessage, kafka.Message, error) {
+	var (
+		found bool
+		m     DLQMessage
+		raw   kafka.Message
+	)
+	_, err := fetchRecords(ctx, kafka.TCP(kafkaBrokers), topic, ref.Partition, ref.Offset, kafka.ReadCommitted, func(rec *protocol.Record) (bool, error) {
+		if rec.Offset != ref.Offset {
+			return false, nil
+		}
+		var err error
+		m, raw, err = dlqRecord(topic, ref.Partition, rec, names)
+		found = err == nil
+		return false, err
+	})
+	switch {
+	case errors.Is(err, kafka.OffsetOutOfRange):
+		return m, raw, errDLQMessageNotFound
+	case err != nil:
+		return m, raw, err
+	case !found:
+		return m, raw, errDLQMessageNotFound
+	}
+	return m, raw, nil
+}
+
+// dlqRecord 프로토콜 레코드를 DLQ 응답 구조와 원본 메시지로 변환 (null 키/값 유지)
+func dlqRecord(topic string, partition int, rec *protocol.Record, names dlqHeaderNames) (DLQMessage, kafka.Message, error) {
+	raw := kafka.Message{Topic: topic, Partition: partition, Offset: rec.Offset, Time: rec.Time, Headers: rec.Headers}
+	var err error
+	if raw.Key, err = recordBytes(rec.Key); err != nil {
+		return DLQMessage{}, raw, err
+	}
+	if raw.Value, err = recordBytes(rec.Value); err != nil {
+		return DLQMessage{}, raw, err
+	}
+
+	m := DLQMessage{
+		ConsumedMessage: ConsumedMessage{
+			Topic:     topic,
+			Partition: partition,
+			Offset:    rec.Offset,
+			Key:       nullableString(raw.Key),
+			Value:     nullableString(raw.Value),
+			Timestamp: rec.Time,
+		},
+		Headers:       make([]MessageHeader, len(raw.Headers)),
+		Error:         names.errorOf(raw.Headers),
+		OriginalTopic: names.originalTopicOf(topic, raw.Headers),
+		Status:        "pending",
+	}
+	for i, h := range raw.Headers {
+		m.Headers[i] = MessageHeader{Key: h.Key, Value: string(h.Value), NullValue: h.Value == nil}
+	}
+	return m, raw, nil
+}
+
+// nullableString null이면 nil, 아니면 빈 문자열도 값으로
+func nullableString(b []byte) *string {
+	if b == nil {
+		return nil
+	}
+	s := string(b)
+	return &s
+}
+
+// applyActions 처리 이력으로 상태 설정 (마지막 처리 기준)
+func (m *DLQMessage) applyActions(history []DLQAction) {
+	if len(history) == 0 {
+		return
+	}
+	last := history[len(history)-1]
+	m.LastAction = &last
+	m.Status = "redriven"
+	if last.Action == "skip" {
+		m.Status = "skipped"
+	}
+}
+
+// groupDLQMessages 오류/원본 토픽별 묶음 (메시지 수 내림차순)
+func groupDLQMessages(messages []DLQMessage) []DLQGroup {
+	type groupKey struct{ error, originalTopic string }
+	byKey := make(map[groupKey]*DLQGroup)
+	var groups []*DLQGroup
+	for _, m := range messages {
+		key := groupKey{m.Error, m.OriginalTopic}
+		g := byKey[key]
+		if g == nil {
+			g = &DLQGroup{Error: m.Error, OriginalTopic: m.OriginalTopic, FirstTimestamp: m.Timestamp, LastTimestamp: m.Timestamp}
+			byKey[key] = g
+			groups = append(groups, g)
+		}
+		g.Count++
+		switch m.Status {
+		case "redriven":
+			g.Redriven++
+		case "skipped":
+			g.Skipped++
+		default:
+			g.Pending++
+		}
+		if m.Timestamp.Before(g.FirstTimestamp) {
+			g.FirstTimestamp = m.Timestamp
+		}
+		if m.Timestamp.After(g.LastTimestamp) {
+			g.LastTimestamp = m.Timestamp
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].Count > groups[j].Count })
+	result := make([]DLQGroup, len(groups))
+	for i, g := range groups {
+		result[i] = *g
+	}
+	return result
+}
+
+// errorOf 오류 헤더 값 (같은 헤더가 여러 개면 마지막 값)
+func (n dlqHeaderNames) errorOf(headers []kafka.Header) string {
+	if n.errorHeader != "" {
+		v, _ := lastHeader(headers, n.errorHeader)
+		return v
+	}
+	for _, name := range dlqErrorHeaders {
+		if v, ok := lastHeader(headers, name); ok {
+			return v
+		}
+	}
+	return ""
+}
+
+// originalTopicOf 원본 토픽 (헤더가 없으면 DLQ 토픽 이름에서 접미사를 뗀 토픽)
+func (n dlqHeaderNames) originalTopicOf(topic string, headers []kafka.Header) string {
+	candidates := dlqOriginalTopicHeaders
+	if n.originalTopicHeader != "" {
+		candidates = []string{n.originalTopicHeader}
+	}
+	for _, name := range candidates {
+		if v, ok := lastHeader(headers, name); ok && v != "" {
+			return v
+		}
+	}
+	return strings.TrimSuffix(topic, dlqTopicSuffix)
+}
+
+// isDLQHeader 재전송 시 제거할 DLQ 헤더 여부
+func (n dlqHeaderNames) isDLQHeader(key string) bool {
+	if key == dlqRedriveHeader || key == n.errorHeader || key == n.originalTopicHeader {
+		return true
+	}
+	for _, prefix := range dlqHeaderPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	for _, names := range [][]string{dlqErrorHeaders, dlqOriginalTopicHeaders} {
+		for _, name := range names {
+			if key == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lastHeader 헤더의 마지막 값
+func lastHeader(headers []kafka.Header, key string) (string, bool) {
+	for i := len(headers) - 1; i >= 0; i-- {
+		if headers[i].Key == key {
+			return string(headers[i].Value), true
+		}
+	}
+	return "", false
+}
+
+// validate 수정 내용 확인
+func (m DLQRedriveMessage) validate() error {
+	if m.NullKey && m.Key != nil {
+		return errors.New("key must be omitted when nullKey is true")
+	}
+	if m.NullValue && m.Value != nil {
+		return errors.New("value must be omitted when nullValue is true")
+	}
+	if m.Headers != nil {
+		for _, h := range *m.Headers {
+			if h.Key == "" {
+				return errors.New("header key is required")
+			}
+		}
+	}
+	return nil
+}
+
+// message 재전송할 메시지 생성 (수정 여부 함께 반환)
+func (m DLQRedriveMessage) message(topic string, raw kafka.Message, names dlqHeaderNames, keepHeaders bool, at time.Time) (kafka.Message, bool) {
+	msg := kafka.Message{Key: raw.Key, Value: raw.Value, Time: at}
+	edited := false
+
+	switch {
+	case m.NullKey:
+		msg.Key, edited = nil, true
+	case m.Key != nil:
+		msg.Key, edited = []byte(*m.Key), true
+	}
+	switch {
+	case m.NullValue:
+		msg.Value, edited = nil, true
+	case m.Value != nil:
+		msg.Value, edited = []byte(*m.Value), true
+	}
+
+	if m.Headers != nil {
+		edited = true
+		for _, h := range *m.Headers {
+			msg.Headers = append(msg.Headers, kafka.Header{Key: h.Key, Value: nullableBytes(h.Value, h.NullValue)})
+		}
+	} else {
+		for _, h := range raw.Headers {
+			if (keepHeaders && h.Key != dlqRedriveHeader) || !names.isDLQHeader(h.Key) {
+				msg.Headers = append(msg.Headers, h)
+			}
+		}
+	}
+	msg.Headers = append(msg.Headers, kafka.Header{
+		Key:   dlqRedriveHeader,
+		Value: []byte(fmt.Sprintf("%s/%d/%d", topic, raw.Partition, raw.Offset)),
+	})
+	return msg, edited
+}
+
+// dlqIndex DLQ 토픽별 메시지 처리 이력 (시작 시 감사 기록 파일에서 한 번 읽고 이후 recordDLQAction으로 갱신)
+//
+// 이력은 이 프로세스의 메모리에만 있으므로, 여러 인스턴스를 띄우면 다른 인스턴스에서
+// 처리한 메시지는 재시작해 감사 기록 파일을 다시 읽기 전까지 보이지 않는다.
+var dlqIndex struct {
+	mu      sync.RWMutex
+	actions map[string]map[DLQMessageRef][]DLQAction
+}
+
+// dlqTopicLocks DLQ 토픽별 재전송/건너뛰기 잠금 (사용 중인 토픽만 유지)
+var dlqTopicLocks struct {
+	mu    sync.Mutex
+	locks map[string]*dlqTopicLock
+}
+
+type dlqTopicLock struct {
+	sync.Mutex
+	refs int
+}
+
+// lockDLQTopic DLQ 토픽의 재전송/건너뛰기 잠금을 잡고 해제 함수 반환
+func lockDLQTopic(topic string) func() {
+	dlqTopicLocks.mu.Lock()
+	if dlqTopicLocks.locks == nil {
+		dlqTopicLocks.locks = make(map[string]*dlqTopicLock)
+	}
+	l := dlqTopicLocks.locks[topic]
+	if l == nil {
+		l = &dlqTopicLock{}
+		dlqTopicLocks.locks[topic] = l
+	}
+	l.refs++
+	dlqTopicLocks.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		dlqTopicLocks.mu.Lock()
+		if l.refs--; l.refs == 0 {
+			delete(dlqTopicLocks.locks, topic)
+		}
+		dlqTopicLocks.mu.Unlock()
+	}
+}
+
+// LoadDLQActions 감사 기록 파일에서 성공한 DLQ 재전송/건너뛰기 이력을 읽어 색인 (서버 시작 시 한 번 호출)
+func LoadDLQActions() error {
+	auditLog.mu.Lock()
+	path := auditLog.path
+	auditLog.mu.Unlock()
+	if path == "" {
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 4*auditMaxBodyBytes)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		// 대부분의 기록은 JSON 해석 없이 건너뜀
+		if !strings.Contains(string(line), `"dlq.message.`) {
+			continue
+		}
+		var record AuditRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			continue
+		}
+		indexDLQAction(record)
+	}
+	return scanner.Err()
+}
+
+// recordDLQAction 메시지별 재전송/건너뛰기 감사 기록 (errMsg가 있으면 실패로 기록, 성공하면 색인에도 추가)
+func recordDLQAction(c *gin.Context, action, topic string, ref DLQMessageRef, reason string, params map[string]interface{}, errMsg string) {
+	params["partition"] = ref.Partition
+	params["offset"] = ref.Offset
+	if reason != "" {
+		params["reason"] = reason
+	}
+
+	record := AuditRecord{
+		Time:       time.Now().UTC(),
+		Actor:      "unknown",
+		RemoteAddr: c.ClientIP(),
+		Action:     action,
+		Target:     topic,
+		Params:     params,
+		Status:     http.StatusOK,
+		Result:     "success",
+	}
+	if principal := auth.PrincipalFrom(c); principal != nil {
+		record.Actor = principal.Name
+		record.AuthMethod = principal.Method
+	}
+	if errMsg != "" {
+		record.Status, record.Result, record.Error = http.StatusInternalServerError, "failure", errMsg
+	}
+	RecordAudit(record)
+	indexDLQAction(record)
+}
+
+// indexDLQAction 성공한 DLQ 재전송/건너뛰기 기록을 색인에 추가 (그 밖의 기록은 무시)
+func indexDLQAction(record AuditRecord) {
+	if record.Result != "success" {
+		return
+	}
+
+	action := DLQAction{Actor: record.Actor, Time: record.Time}
+	switch record.Action {
+	case dlqActionRedrive:
+		action.Action = "redrive"
+	case dlqActionSkip:
+		action.Action = "skip"
+	default:
+		return
+	}
+	partition, ok1 := auditInt(record.Params, "partition")
+	offset, ok2 := auditInt(record.Params, "offset")
+	if !ok1 || !ok2 {
+		return
+	}
+	action.Reason, _ = record.Params["reason"].(string)
+	action.TargetTopic, _ = record.Params["target_topic"].(string)
+	action.Edited, _ = record.Params["edited"].(bool)
+	if v, ok := auditInt(record.Params, "target_partition"); ok {
+		p := int(v)
+		action.TargetPartition = &p
+	}
+	if v, ok := auditInt(record.Params, "target_offset"); ok {
+		action.TargetOffset = &v
+	}
+
+	dlqIndex.mu.Lock()
+	defer dlqIndex.mu.Unlock()
+	if dlqIndex.actions == nil {
+		dlqIndex.actions = make(map[string]map[DLQMessageRef][]DLQAction)
+	}
+	actions := dlqIndex.actions[record.Target]
+	if actions == nil {
+		actions = make(map[DLQMessageRef][]DLQAction)
+		dlqIndex.actions[record.Target] = actions
+	}
+	ref := DLQMessageRef{Partition: int(partition), Offset: offset}
+	actions[ref] = append(actions[ref], action)
+}
+
+// dlqActions DLQ 토픽 메시지별 성공한 재전송/건너뛰기 이력 (오래된 순, 색인의 복사본)
+func dlqActions(topic string) map[DLQMessageRef][]DLQAction {
+	dlqIndex.mu.RLock()
+	defer dlqIndex.mu.RUnlock()
+
+	actions := make(map[DLQMessageRef][]DLQAction, len(dlqIndex.actions[topic]))
+	for ref, history := range dlqIndex.actions[topic] {
+		actions[ref] = slices.Clone(history)
+	}
+	return actions
+}
+
+// auditInt 감사 기록 파라미터의 정수 값 (파일에서 읽은 JSON 숫자는 float64)
+func auditInt(params map[string]interface{}, key string) (int64, bool) {
+	switch v := params[key].(type) {
+	case float64:
+		return int64(v), true
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	}
+	return 0, false
+}
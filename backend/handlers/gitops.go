@@ -0,0 +1,707 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
+	"gopkg.in/yaml.v3"
+)
+
+// GitOps 변경 유형 (적용 순서대로)
+const (
+	gitOpsCreateTopic        = "create_topic"
+	gitOpsIncreasePartitions = "increase_partitions"
+	gitOpsAlterConfig        = "alter_config"
+	gitOpsCreateACL          = "create_acl"
+	gitOpsDeleteACL          = "delete_acl"
+	gitOpsDeleteTopic        = "delete_topic"
+)
+
+// gitOpsActionOrder 적용 순서 (삭제는 생성/변경 이후)
+var gitOpsActionOrder = map[string]int{
+	gitOpsCreateTopic:        0,
+	gitOpsIncreasePartitions: 1,
+	gitOpsAlterConfig:        2,
+	gitOpsCreateACL:          3,
+	gitOpsDeleteACL:          4,
+	gitOpsDeleteTopic:        5,
+}
+
+// DesiredState 원하는 클러스터 상태 (YAML 또는 JSON)
+type DesiredState struct {
+	Topics []DesiredTopic `json:"topics"`
+	// ACLs 생략하면 ACL은 관리하지 않음 (빈 목록은 ACL이 없어야 함을 의미)
+	ACLs *[]ACLRule `json:"acls"`
+}
+
+// DesiredTopic 원하는 토픽 상태
+type DesiredTopic struct {
+	Name              string `json:"name"`
+	Partitions        int    `json:"partitions"`
+	ReplicationFactor int    `json:"replicationFactor"`
+	// Configs 토픽 설정 (YAML의 숫자/불리언 값은 문자열로 변환)
+	Configs map[string]interface{} `json:"configs"`
+}
+
+// GitOpsChange 계획된 변경 항목
+type GitOpsChange struct {
+	Action            string                `json:"action"`
+	Topic             string                `json:"topic,omitempty"`
+	Partitions        int                   `json:"partitions,omitempty"`
+	OldPartitions     int                   `json:"old_partitions,omitempty"`
+	ReplicationFactor int                   `json:"replication_factor,omitempty"`
+	Configs           []ConfigChangePreview `json:"configs,omitempty"`
+	ACL               *ACLBinding           `json:"acl,omitempty"`
+}
+
+// GitOpsPlan 원하는 상태와 실제 클러스터 간 변경 계획
+type GitOpsPlan struct {
+	// PlanID 변경 내용의 해시, 적용 시 승인 값으로 사용
+	PlanID      string         `json:"plan_id"`
+	AllowDelete bool           `json:"allow_delete"`
+	Changes     []GitOpsChange `json:"changes"`
+	Diff        string         `json:"diff"`
+	// Unmanaged allowDelete가 아니어서 삭제하지 않는 항목
+	Unmanaged []string `json:"unmanaged"`
+	// Errors 적용할 수 없는 변경 (있으면 적용 거부)
+	Errors []string `json:"errors"`
+}
+
+// GitOpsResult 변경 항목별 적용 결과
+type GitOpsResult struct {
+	GitOpsChange
+	Status string `json:"status"` // applied, failed
+	Error  string `json:"error,omitempty"`
+}
+
+// PlanGitOps 원하는 상태 파일로 변경 계획 생성 (?allowDelete=true면 삭제 포함)
+func PlanGitOps(c *gin.Context) {
+	plan, ok := planFromRequest(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
+
+// ApplyGitOps 승인된 계획 적용 (?approve=<plan_id>, 계획 이후 상태가 바뀌었으면 거부)
+func ApplyGitOps(c *gin.Context) {
+	plan, ok := planFromRequest(c)
+	if !ok {
+		return
+	}
+
+	if len(plan.Errors) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error": "Plan contains changes that cannot be applied",
+			"plan":  plan,
+		})
+		return
+	}
+
+	approve := c.Query("approve")
+	if approve == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "approve is required; review the plan and pass its plan_id as approve",
+			"plan":  plan,
+		})
+		return
+	}
+	if approve != plan.PlanID {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Plan changed since it was approved (cluster or desired state differs); review the new plan",
+			"plan":  plan,
+		})
+		return
+	}
+
+	results := ApplyGitOpsPlan(plan)
+
+	failed := 0
+	for _, r := range results {
+		if r.Status == "failed" {
+			failed++
+		}
+	}
+
+	code, status := http.StatusOK, "success"
+	if failed > 0 {
+		code, status = http.StatusMultiStatus, "partial"
+		if failed == len(results) {
+			code, status = http.StatusInternalServerError, "failed"
+		}
+	}
+
+	c.JSON(code, gin.H{
+		"status":  status,
+		"plan_id": plan.PlanID,
+		"results": results,
+		"failed":  failed,
+	})
+}
+
+// planFromRequest 요청 본문의 원하는 상태를 파싱해 계획 생성 (실패 시 응답 작성)
+func planFromRequest(c *gin.Context) (*GitOpsPlan, bool) {
+	data, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	state, err := ParseDesiredState(data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("Invalid desired state: %v", err),
+		})
+		return nil, false
+	}
+
+	plan, err := BuildGitOpsPlan(state, c.Query("allowDelete") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to build plan: %v", err),
+		})
+		return nil, false
+	}
+
+	return plan, true
+}
+
+// ParseDesiredState YAML/JSON 원하는 상태 파싱
+func ParseDesiredState(data []byte) (*DesiredState, error) {
+	var state DesiredState
+	if err := decodeYAMLOrJSON(data, &state); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(state.Topics))
+	for i, t := range state.Topics {
+		switch {
+		case t.Name == "":
+			return nil, fmt.Errorf("topics[%d]: name is required", i)
+		case seen[t.Name]:
+			return nil, fmt.Errorf("topics[%d]: duplicate topic %q", i, t.Name)
+		case t.Partitions < 1:
+			return nil, fmt.Errorf("topic %q: partitions must be at least 1", t.Name)
+		case t.ReplicationFactor < 1:
+			return nil, fmt.Errorf("topic %q: replicationFactor must be at least 1", t.Name)
+		}
+		for name, value := range t.Configs {
+			if value == nil {
+				return nil, fmt.Errorf("topic %q: config %q has no value", t.Name, name)
+			}
+		}
+		seen[t.Name] = true
+	}
+
+	if state.ACLs != nil {
+		for i, rule := range *state.ACLs {
+			rule, err := normalizeACLRule(rule)
+			if err != nil {
+				return nil, fmt.Errorf("acls[%d]: %v", i, err)
+			}
+			(*state.ACLs)[i] = rule
+		}
+	}
+
+	return &state, nil
+}
+
+// BuildGitOpsPlan 실제 클러스터 상태와 비교해 변경 계획 생성
+func BuildGitOpsPlan(state *DesiredState, allowDelete bool) (*GitOpsPlan, error) {
+	plan := &GitOpsPlan{
+		AllowDelete: allowDelete,
+		Changes:     []GitOpsChange{},
+		Unmanaged:   []string{},
+		Errors:      []string{},
+	}
+
+	partitions, err := readPartitions("")
+	if err != nil {
+		return nil, err
+	}
+
+	// 토픽별 파티션 수와 복제 계수
+	type liveTopic struct {
+		partitions        int
+		replicationFactor int
+	}
+	live := make(map[string]*liveTopic)
+	for _, p := range partitions {
+		t, ok := live[p.Topic]
+		if !ok {
+			t = &liveTopic{}
+			live[p.Topic] = t
+		}
+		t.partitions++
+		if len(p.Replicas) > t.replicationFactor {
+			t.replicationFactor = len(p.Replicas)
+		}
+	}
+
+	desired := make(map[string]bool, len(state.Topics))
+	for _, t := range state.Topics {
+		desired[t.Name] = true
+		configs := stringifyConfigs(t.Configs)
+
+		current, ok := live[t.Name]
+		if !ok {
+			change := GitOpsChange{
+				Action:            gitOpsCreateTopic,
+				Topic:             t.Name,
+				Partitions:        t.Partitions,
+				ReplicationFactor: t.ReplicationFactor,
+			}
+			for _, name := range sortedKeys(configs) {
+				value := configs[name]
+				change.Configs = append(change.Configs, ConfigChangePreview{
+					Name: name, Op: "set", NewValue: &value, Source: "dynamic_topic",
+				})
+			}
+			plan.Changes = append(plan.Changes, change)
+
+			violations := topicPolicy.checkTopic(t.Name, t.Partitions, t.ReplicationFactor)
+			configViolations, err := topicPolicy.checkConfigs(t.ReplicationFactor, configs)
+			if err != nil {
+				return nil, fmt.Errorf("check topic policy: %w", err)
+			}
+			addPolicyErrors(plan, t.Name, append(violations, configViolations...))
+			continue
+		}
+
+		if t.ReplicationFactor != current.replicationFactor {
+			plan.Errors = append(plan.Errors, fmt.Sprintf(
+				"topic %q: replication factor %d -> %d requires a partition reassignment (see /api/reassignments)",
+				t.Name, current.replicationFactor, t.ReplicationFactor))
+		}
+
+		switch {
+		case t.Partitions < current.partitions:
+			plan.Errors = append(plan.Errors, fmt.Sprintf(
+				"topic %q: partitions cannot be decreased (%d -> %d)", t.Name, current.partitions, t.Partitions))
+		case t.Partitions > current.partitions:
+			plan.Changes = append(plan.Changes, GitOpsChange{
+				Action:        gitOpsIncreasePartitions,
+				Topic:         t.Name,
+				Partitions:    t.Partitions,
+				OldPartitions: current.partitions,
+			})
+			addPolicyErrors(plan, t.Name, topicPolicy.checkPartitions(t.Partitions))
+		}
+
+		previews, unmanaged, after, err := planTopicConfigs(t.Name, configs, allowDelete)
+		if err != nil {
+			return nil, fmt.Errorf("topic %q: %w", t.Name, err)
+		}
+		if len(previews) > 0 {
+			plan.Changes = append(plan.Changes, GitOpsChange{
+				Action:  gitOpsAlterConfig,
+				Topic:   t.Name,
+				Configs: previews,
+			})
+
+			violations, err := topicPolicy.checkConfigs(current.replicationFactor, after)
+			if err != nil {
+				return nil, fmt.Errorf("check topic policy: %w", err)
+			}
+			addPolicyErrors(plan, t.Name, violations)
+		}
+		plan.Unmanaged = append(plan.Unmanaged, unmanaged...)
+	}
+
+	liveNames := make([]string, 0, len(live))
+	for name := range live {
+		liveNames = append(liveNames, name)
+	}
+	sort.Strings(liveNames)
+
+	for _, name := range liveNames {
+		// 내부 토픽(__consumer_offsets 등)과 보호 토픽은 관리 대상이 아님
+		if desired[name] || protectedTopicReason(name) != "" {
+			continue
+		}
+		if !allowDelete {
+			plan.Unmanaged = append(plan.Unmanaged, "topic "+name)
+			continue
+		}
+		plan.Changes = append(plan.Changes, GitOpsChange{Action: gitOpsDeleteTopic, Topic: name})
+	}
+
+	if state.ACLs != nil {
+		if err := planACLs(plan, *state.ACLs, allowDelete); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(plan.Changes, func(i, j int) bool {
+		return gitOpsActionOrder[plan.Changes[i].Action] < gitOpsActionOrder[plan.Changes[j].Action]
+	})
+
+	plan.Diff = renderGitOpsDiff(plan.Changes)
+
+	// 계획 ID는 변경 내용과 오류로 결정되므로 상태가 바뀌면 달라짐
+	encoded, err := json.Marshal(struct {
+		Changes []GitOpsChange
+		Errors  []string
+	}{plan.Changes, plan.Errors})
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(encoded)
+	plan.PlanID = hex.EncodeToString(sum[:8])
+
+	return plan, nil
+}
+
+// ApplyGitOpsPlan 계획의 변경을 순서대로 적용 (실패해도 나머지는 계속 진행)
+func ApplyGitOpsPlan(plan *GitOpsPlan) []GitOpsResult {
+	results := make([]GitOpsResult, 0, len(plan.Changes))
+	for _, change := range plan.Changes {
+		result := GitOpsResult{GitOpsChange: change, Status: "applied"}
+		if err := applyGitOpsChange(change); err != nil {
+			result.Status = "failed"
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// applyGitOpsChange 변경 항목 하나 적용
+func applyGitOpsChange(change GitOpsChange) error {
+	client := newKafkaClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	switch change.Action {
+	case gitOpsCreateTopic:
+		configs := make(map[string]string, len(change.Configs))
+		for _, cfg := range change.Configs {
+			configs[cfg.Name] = *cfg.NewValue
+		}
+		resp, err := client.CreateTopics(ctx, &kafka.CreateTopicsRequest{
+			Topics: []kafka.TopicConfig{{
+				Topic:             change.Topic,
+				NumPartitions:     change.Partitions,
+				ReplicationFactor: change.ReplicationFactor,
+				ConfigEntries:     toConfigEntries(configs),
+			}},
+		})
+		if err != nil {
+			return err
+		}
+		return resp.Errors[change.Topic]
+
+	case gitOpsIncreasePartitions:
+		resp, err := client.CreatePartitions(ctx, &kafka.CreatePartitionsRequest{
+			Topics: []kafka.TopicPartitionsConfig{{
+				Name:  change.Topic,
+				Count: int32(change.Partitions),
+			}},
+		})
+		if err != nil {
+			return err
+		}
+		return resp.Errors[change.Topic]
+
+	case gitOpsAlterConfig:
+		changes := make([]ConfigChange, 0, len(change.Configs))
+		for _, cfg := range change.Configs {
+			cc := ConfigChange{Name: cfg.Name, Op: cfg.Op}
+			if cfg.NewValue != nil {
+				cc.Value = *cfg.NewValue
+			}
+			changes = append(changes, cc)
+		}
+		return incrementalAlterConfigs(kafka.ResourceTypeTopic, change.Topic, changes, false)
+
+	case gitOpsCreateACL:
+		acl := change.ACL
+		resp, err := client.CreateACLs(ctx, &kafka.CreateACLsRequest{
+			ACLs: []kafka.ACLEntry{{
+				ResourceType:        acl.ResourceType,
+				ResourceName:        acl.ResourceName,
+				ResourcePatternType: acl.PatternType,
+				Principal:           acl.Principal,
+				Host:                acl.Host,
+				Operation:           acl.Operation,
+				PermissionType:      acl.Permission,
+			}},
+		})
+		if err != nil {
+			return err
+		}
+		for _, e := range resp.Errors {
+			if e != nil {
+				return e
+			}
+		}
+		return nil
+
+	case gitOpsDeleteACL:
+		acl := change.ACL
+		resp, err := client.DeleteACLs(ctx, &kafka.DeleteACLsRequest{
+			Filters: []kafka.DeleteACLsFilter{{
+				ResourceTypeFilter:        acl.ResourceType,
+				ResourceNameFilter:        acl.ResourceName,
+				ResourcePatternTypeFilter: acl.PatternType,
+				PrincipalFilter:           acl.Principal,
+				HostFilter:                acl.Host,
+				Operation:                 acl.Operation,
+				PermissionType:            acl.Permission,
+			}},
+		})
+		if err != nil {
+			return err
+		}
+		for _, r := range resp.Results {
+			if r.Error != nil {
+				return r.Error
+			}
+		}
+		return nil
+
+	case gitOpsDeleteTopic:
+		resp, err := client.DeleteTopics(ctx, &kafka.DeleteTopicsRequest{Topics: []string{change.Topic}})
+		if err != nil {
+			return err
+		}
+		return resp.Errors[change.Topic]
+	}
+
+	return fmt.Errorf("unknown action %q", change.Action)
+}
+
+// planTopicConfigs 토픽 설정 변경 계획 (토픽에 직접 지정된 설정만 관리 대상, 변경 후 설정도 반환)
+func planTopicConfigs(topic string, desired map[string]string, allowDelete bool) ([]ConfigChangePreview, []string, map[string]string, error) {
+	current, err := describeConfigs(kafka.ResourceTypeTopic, topic)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	byName := make(map[string]ConfigEntry, len(current))
+	for _, e := range current {
+		byName[e.Name] = e
+	}
+
+	var previews []ConfigChangePreview
+	var unmanaged []string
+	for _, name := range sortedKeys(desired) {
+		value := desired[name]
+		entry, ok := byName[name]
+		if ok && entry.Source == "dynamic_topic" && entry.Value == value {
+			continue
+		}
+		previews = append(previews, ConfigChangePreview{
+			Name:     name,
+			Op:       "set",
+			OldValue: entry.Value,
+			NewValue: &value,
+			Source:   entry.Source,
+		})
+	}
+
+	for _, e := range current {
+		if e.Source != "dynamic_topic" {
+			continue
+		}
+		if _, ok := desired[e.Name]; ok {
+			continue
+		}
+		if !allowDelete {
+			unmanaged = append(unmanaged, fmt.Sprintf("config %s %s=%s", topic, e.Name, e.Value))
+			continue
+		}
+		previews = append(previews, ConfigChangePreview{
+			Name:     e.Name,
+			Op:       "delete",
+			OldValue: e.Value,
+			Source:   e.Source,
+		})
+	}
+
+	return previews, unmanaged, topicConfigsAfter(current, previews), nil
+}
+
+// addPolicyErrors 정책 위반을 계획 오류로 추가
+func addPolicyErrors(plan *GitOpsPlan, topic string, violations []PolicyViolation) {
+	for _, v := range violations {
+		plan.Errors = append(plan.Errors, fmt.Sprintf("topic %q: policy %s: %s", topic, v.Rule, v.Message))
+	}
+}
+
+// planACLs ACL 생성/삭제 계획 추가
+func planACLs(plan *GitOpsPlan, desired []ACLRule, allowDelete bool) error {
+	current, err := describeACLs(kafka.ACLFilter{
+		ResourceTypeFilter:        kafka.ResourceTypeAny,
+		ResourcePatternTypeFilter: kafka.PatternTypeAny,
+		Operation:                 kafka.ACLOperationTypeAny,
+		PermissionType:            kafka.ACLPermissionTypeAny,
+	})
+	if err != nil {
+		// authorizer가 없는 클러스터에서 ACL이 비어 있으면 비교할 것이 없음
+		if errors.Is(err, kafka.SecurityDisabled) && len(desired) == 0 {
+			return nil
+		}
+		return fmt.Errorf("describe ACLs: %w", err)
+	}
+
+	existing := make(map[string]bool, len(current))
+	for _, acl := range current {
+		existing[aclKey(acl)] = true
+	}
+
+	wanted := make(map[string]bool, len(desired))
+	for _, rule := range desired {
+		acl := ACLBinding{
+			ResourceType: rule.ResourceType,
+			ResourceName: rule.ResourceName,
+			PatternType:  rule.PatternType,
+			Principal:    rule.Principal,
+			Host:         rule.Host,
+			Operation:    rule.Operation,
+			Permission:   rule.Permission,
+		}
+		key := aclKey(acl)
+		if wanted[key] {
+			continue
+		}
+		wanted[key] = true
+		if !existing[key] {
+			plan.Changes = append(plan.Changes, GitOpsChange{Action: gitOpsCreateACL, ACL: &acl})
+		}
+	}
+
+	for i := range current {
+		acl := current[i]
+		if wanted[aclKey(acl)] {
+			continue
+		}
+		if !allowDelete {
+			plan.Unmanaged = append(plan.Unmanaged, "acl "+aclKey(acl))
+			continue
+		}
+		plan.Changes = append(plan.Changes, GitOpsChange{Action: gitOpsDeleteACL, ACL: &acl})
+	}
+
+	return nil
+}
+
+// decodeYAMLOrJSON YAML/JSON 문서를 JSON 태그 기준으로 디코딩 (알 수 없는 필드는 오류)
+func decodeYAMLOrJSON(data []byte, v interface{}) error {
+	// YAML은 JSON의 상위 집합이므로 YAML로 읽은 뒤 JSON 태그로 디코딩
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		return errors.New("empty document")
+	}
+
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.DisallowUnknownFields()
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// renderGitOpsDiff 변경 계획을 사람이 읽기 쉬운 diff 형식으로 표시 (+ 생성, ~ 변경, - 삭제)
+func renderGitOpsDiff(changes []GitOpsChange) string {
+	var b strings.Builder
+	for _, change := range changes {
+		switch change.Action {
+		case gitOpsCreateTopic:
+			fmt.Fprintf(&b, "+ topic %s (partitions=%d, replicationFactor=%d)\n",
+				change.Topic, change.Partitions, change.ReplicationFactor)
+			for _, cfg := range change.Configs {
+				fmt.Fprintf(&b, "+   %s=%s\n", cfg.Name, *cfg.NewValue)
+			}
+		case gitOpsIncreasePartitions:
+			fmt.Fprintf(&b, "~ topic %s partitions: %d -> %d\n", change.Topic, change.OldPartitions, change.Partitions)
+		case gitOpsAlterConfig:
+			fmt.Fprintf(&b, "~ topic %s config\n", change.Topic)
+			for _, cfg := range change.Configs {
+				if cfg.NewValue == nil {
+					fmt.Fprintf(&b, "-   %s=%s\n", cfg.Name, cfg.OldValue)
+					continue
+				}
+				if cfg.Source == "dynamic_topic" {
+					fmt.Fprintf(&b, "~   %s: %s -> %s\n", cfg.Name, cfg.OldValue, *cfg.NewValue)
+				} else {
+					fmt.Fprintf(&b, "+   %s=%s (was %s from %s)\n", cfg.Name, *cfg.NewValue, cfg.OldValue, cfg.Source)
+				}
+			}
+		case gitOpsCreateACL:
+			fmt.Fprintf(&b, "+ acl %s\n", aclKey(*change.ACL))
+		case gitOpsDeleteACL:
+			fmt.Fprintf(&b, "- acl %s\n", aclKey(*change.ACL))
+		case gitOpsDeleteTopic:
+			fmt.Fprintf(&b, "- topic %s\n", change.Topic)
+		}
+	}
+	return b.String()
+}
+
+// normalizeACLRule ACL 규칙 기본값 적용 및 검증
+func normalizeACLRule(rule ACLRule) (ACLRule, error) {
+	if rule.PatternType == kafka.PatternTypeUnknown {
+		rule.PatternType = kafka.PatternTypeLiteral
+	}
+	if rule.Host == "" {
+		rule.Host = "*"
+	}
+
+	if rule.ResourceName == "" || rule.Principal == "" {
+		return rule, errors.New("resourceName and principal are required")
+	}
+	if rule.ResourceType <= kafka.ResourceTypeAny ||
+		(rule.PatternType != kafka.PatternTypeLiteral && rule.PatternType != kafka.PatternTypePrefixed) ||
+		rule.Operation <= kafka.ACLOperationTypeAny ||
+		(rule.Permission != kafka.ACLPermissionTypeAllow && rule.Permission != kafka.ACLPermissionTypeDeny) {
+		return rule, errors.New("resourceType, operation and permission (allow/deny) must be concrete values and patternType must be literal or prefixed")
+	}
+
+	return rule, nil
+}
+
+// aclKey ACL 비교용 식별 문자열
+func aclKey(acl ACLBinding) string {
+	return fmt.Sprintf("%s %s:%s:%s %s@%s %s",
+		acl.Permission, acl.ResourceType, acl.PatternType, acl.ResourceName,
+		acl.Principal, acl.Host, acl.Operation)
+}
+
+// stringifyConfigs YAML/JSON 설정 값을 문자열로 변환
+func stringifyConfigs(configs map[string]interface{}) map[string]string {
+	result := make(map[string]string, len(configs))
+	for name, value := range configs {
+		result[name] = fmt.Sprint(value)
+	}
+	return result
+}
+
+// sortedKeys map 키를 정렬해 반환
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
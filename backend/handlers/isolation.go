@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/protocol"
+	"github.com/segmentio/kafka-go/protocol/fetch"
+)
+
+// controlRecordAbort 트랜잭션 중단 표시의 control record 타입 (1은 커밋)
+const controlRecordAbort = 0
+
+// parseIsolation 격리 수준 (read_uncommitted 기본, read_committed는 커밋된 트랜잭션만)
+func parseIsolation(s string) (kafka.IsolationLevel, error) {
+	switch s {
+	case "", "read_uncommitted":
+		return kafka.ReadUncommitted, nil
+	case "read_committed":
+		return kafka.ReadCommitted, nil
+	}
+	return 0, fmt.Errorf("unknown isolation %q (expected read_uncommitted or read_committed)", s)
+}
+
+// checkStreamingIsolation 실시간 스트리밍(WebSocket, SSE)의 격리 수준 검사 (read_uncommitted만 지원)
+func checkStreamingIsolation(s string) error {
+	isolation, err := parseIsolation(s)
+	if err != nil {
+		return err
+	}
+	if isolation == kafka.ReadCommitted {
+		return fmt.Errorf("isolation read_committed is not supported for streaming, use GET /api/consume?isolation=read_committed")
+	}
+	return nil
+}
+
+// isolationName 응답에 표시할 격리 수준 이름
+func isolationName(level kafka.IsolationLevel) string {
+	if level == kafka.ReadCommitted {
+		return "read_committed"
+	}
+	return "read_uncommitted"
+}
+
+// partitionFetch 파티션 조회 결과
+type partitionFetch struct {
+	messages         []ConsumedMessage
+	highWatermark    int64
+	lastStableOffset int64
+}
+
+// fetchPartition 격리 수준을 적용해 offset부터 최대 max개 메시지 조회 (ctx가 끝날 때까지 대기)
+//
+// offset이 kafka.FirstOffset/LastOffset이면 해당 위치부터 읽는다.
+func fetchPartition(ctx context.Context, topic string, partition int, offset int64, max int, isolation kafka.IsolationLevel) (*partitionFetch, error) {
+	if offset < 0 {
+		resp, err := newKafkaClient().ListOffsets(ctx, &kafka.ListOffsetsRequest{
+			Topics:         map[string][]kafka.OffsetRequest{topic: {{Partition: partition, Timestamp: offset}}},
+			IsolationLevel: isolation,
+		})
+		if err != nil {
+			return nil, err
+		}
+		offsets := resp.Topics[topic]
+		if len(offsets) == 0 {
+			return nil, fmt.Errorf("no offsets returned for %s[%d]", topic, partition)
+		}
+		if offsets[0].Error != nil {
+			return nil, offsets[0].Error
+		}
+		if offset == kafka.FirstOffset {
+			offset = offsets[0].FirstOffset
+		} else {
+			offset = offsets[0].LastOffset
+		}
+	}
+
+	result := &partitionFetch{messages: []ConsumedMessage{}}
+	for len(result.messages) < max {
+		fetched, err := fetchRecords(ctx, kafka.TCP(kafkaBrokers), topic, partition, offset, isolation, func(rec *protocol.Record) (bool, error) {
+			if len(result.messages) >= max {
+				return false, nil
+			}
+			consumed, err := consumedRecord(topic, partition, rec)
+			if err != nil {
+				return false, err
+			}
+			result.messages = append(result.messages, consumed)
+			return true, nil
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				break // 대기 시간 종료
+			}
+			return nil, err
+		}
+		result.highWatermark, result.lastStableOffset = fetched.highWatermark, fetched.lastStableOffset
+		if fetched.next == offset && ctx.Err() != nil {
+			break
+		}
+		offset = fetched.next
+	}
+	return result, nil
+}
+
+// fetchedPartition Fetch 요청 한 번의 결과
+type fetchedPartition struct {
+	next             int64 // 다음 조회 오프셋
+	highWatermark    int64
+	lastStableOffset int64
+}
+
+// fetchRecords offset부터 Fetch 요청 한 번으로 받은 레코드를 격리 수준에 맞게 걸러 순서대로 handle에 전달
+//
+// kafka-go Reader는 트랜잭션 커밋/중단 표시(control record)와 중단된 트랜잭션의
+// 레코드를 일반 메시지처럼 돌려주기 때문에, Fetch 응답의 중단 트랜잭션 목록을 보고
+// Java Consumer와 같은 방식으로 걸러낸다. control record는 격리 수준과 관계없이
+// 건너뛴다. handle이 false를 돌려주면 그 레코드에서 멈추고 그 오프셋을 next로 돌려준다.
+// addr는 다른 클러스터의 브로커일 수 있다.
+func fetchRecords(ctx context.Context, addr net.Addr, topic string, partition int, offset int64, isolation kafka.IsolationLevel, handle func(*protocol.Record) (bool, error)) (*fetchedPartition, error) {
+	msg, err := kafka.DefaultTransport.RoundTrip(ctx, addr, &fetch.Request{
+		ReplicaID:      -1,
+		MaxWaitTime:    1000,
+		MinBytes:       1,
+		MaxBytes:       10e6,
+		IsolationLevel: int8(isolation),
+		SessionID:      -1,
+		SessionEpoch:   -1,
+		Topics: []fetch.RequestTopic{{
+			Topic: topic,
+			Partitions: []fetch.RequestPartition{{
+				Partition:          int32(partition),
+				CurrentLeaderEpoch: -1,
+				FetchOffset:        offset,
+				LogStartOffset:     -1,
+				PartitionMaxBytes:  1e6,
+			}},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := msg.(*fetch.Response)
+	if len(resp.Topics) == 0 || len(resp.Topics[0].Partitions) == 0 {
+		return nil, fmt.Errorf("empty fetch response for %s[%d]", topic, partition)
+	}
+	p := &resp.Topics[0].Partitions[0]
+	if p.ErrorCode != 0 {
+		return nil, kafka.Error(p.ErrorCode)
+	}
+
+	fetched := &fetchedPartition{next: offset, highWatermark: p.HighWatermark, lastStableOffset: p.LastStableOffset}
+	fetched.next, err = readFetchedRecords(offset, p, isolation, handle)
+	return fetched, err
+}
+
+// readFetchedRecords Fetch 응답의 레코드를 격리 수준에 맞게 걸러 handle에 전달하고 다음 조회 오프셋 반환
+func readFetchedRecords(offset int64, p *fetch.ResponsePartition, isolation kafka.IsolationLevel, handle func(*protocol.Record) (bool, error)) (int64, error) {
+	var batches []protocol.RecordReader
+	switch records := p.RecordSet.Records.(type) {
+	case nil:
+		return offset, nil
+	case *protocol.RecordStream:
+		batches = records.Records
+	default:
+		batches = []protocol.RecordReader{records}
+	}
+
+	// 중단된 트랜잭션 (첫 오프셋 순)
+	aborted := append([]fetch.ResponseTransaction(nil), p.AbortedTransactions...)
+	sort.Slice(aborted, func(i, j int) bool { return aborted[i].FirstOffset < aborted[j].FirstOffset })
+	abortedProducers := make(map[int64]bool)
+
+	next := offset
+	for _, batch := range batches {
+		skip := false
+
+		switch b := batch.(type) {
+		case *protocol.ControlBatch:
+			// 커밋/중단 표시는 메시지가 아니므로 건너뛰고, 중단 표시면 해당 Producer의 중단 구간 종료
+			for {
+				cr, err := b.ReadControlRecord()
+				if err != nil {
+					break
+				}
+				if cr.Offset >= next {
+					next = cr.Offset + 1
+				}
+				if cr.Type == controlRecordAbort {
+					delete(abortedProducers, b.ProducerID)
+				}
+			}
+			continue
+		case *protocol.RecordBatch:
+			if isolation == kafka.ReadCommitted && b.Attributes.Transactional() {
+				for len(aborted) > 0 && aborted[0].FirstOffset <= b.BaseOffset {
+					abortedProducers[aborted[0].ProducerID] = true
+					aborted = aborted[1:]
+				}
+				skip = abortedProducers[b.ProducerID]
+			}
+		}
+
+		for {
+			rec, err := batch.ReadRecord()
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					return next, err
+				}
+				break
+			}
+			// 배치는 요청한 오프셋보다 앞에서 시작할 수 있음
+			if rec.Offset < next {
+				continue
+			}
+			if !skip {
+				ok, err := handle(rec)
+				if err != nil {
+					return next, err
+				}
+				if !ok {
+					return rec.Offset, nil
+				}
+			}
+			next = rec.Offset + 1
+		}
+	}
+	return next, nil
+}
+
+// consumedRecord 프로토콜 레코드를 응답 구조로 변환 (null 키/값은 JSON null)
+func consumedRecord(topic string, partition int, rec *protocol.Record) (ConsumedMessage, error) {
+	consumed := ConsumedMessage{
+		Topic:     topic,
+		Partition: partition,
+		Offset:    rec.Offset,
+		Timestamp: rec.Time,
+	}
+	for _, field := range []struct {
+		bytes protocol.Bytes
+		dst   **string
+	}{{rec.Key, &consumed.Key}, {rec.Value, &consumed.Value}} {
+		if field.bytes == nil {
+			continue
+		}
+		b, err := protocol.ReadAll(field.bytes)
+		if err != nil {
+			return consumed, err
+		}
+		s := string(b)
+		*field.dst = &s
+	}
+	return consumed, nil
+}
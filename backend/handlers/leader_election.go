@@ -0,0 +1,309 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/protocol/electleaders"
+)
+
+// 리더 선출 유형 (Kafka ElectionType)
+const (
+	electionTypePreferred int8 = 0
+	electionTypeUnclean   int8 = 1
+)
+
+// TopicPartition 토픽-파티션 식별자
+type TopicPartition struct {
+	Topic     string `json:"topic" binding:"required"`
+	Partition int    `json:"partition"`
+}
+
+// LeaderElectionRequest 리더 선출 요청
+type LeaderElectionRequest struct {
+	// Partitions 선출 대상 (비어 있으면 선호 리더가 아닌 모든 파티션)
+	Partitions []TopicPartition `json:"partitions" binding:"dive"`
+	// Type preferred(기본값) 또는 unclean
+	Type string `json:"type"`
+	// ConfirmUnclean unclean 선출 시 데이터 유실 가능성을 인지했다는 명시적 확인
+	ConfirmUnclean bool `json:"confirmUnclean"`
+}
+
+// SkewedPartition 선호 리더(첫 번째 레플리카)가 리더가 아닌 파티션
+type SkewedPartition struct {
+	Topic           string `json:"topic"`
+	Partition       int    `json:"partition"`
+	Leader          int    `json:"leader"`
+	PreferredLeader int    `json:"preferred_leader"`
+	Replicas        []int  `json:"replicas"`
+	ISR             []int  `json:"isr"`
+	// PreferredInSync 선호 리더가 ISR에 있어 preferred 선출이 가능한지 여부
+	PreferredInSync bool `json:"preferred_in_sync"`
+}
+
+// LeaderElectionResult 파티션별 리더 선출 결과
+type LeaderElectionResult struct {
+	Topic     string `json:"topic"`
+	Partition int    `json:"partition"`
+	Status    string `json:"status"` // elected, not_needed, failed
+	OldLeader int    `json:"old_leader"`
+	NewLeader int    `json:"new_leader"`
+	Error     string `json:"error,omitempty"`
+}
+
+// GetSkewedLeaders 리더가 선호 리더가 아닌 파티션 목록 조회 (?topic= 필터 지원)
+func GetSkewedLeaders(c *gin.Context) {
+	topic := c.Query("topic")
+
+	partitions, err := readPartitions(topic)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to read partitions: %v", err),
+		})
+		return
+	}
+
+	skewed := findSkewedPartitions(partitions)
+
+	// 브로커별 리더 수 (쏠림 확인용)
+	leaderCounts := make(map[int]int)
+	for _, p := range partitions {
+		leaderCounts[p.Leader.ID]++
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"partitions":       skewed,
+		"count":            len(skewed),
+		"total_partitions": len(partitions),
+		"leader_counts":    leaderCounts,
+	})
+}
+
+// ElectLeaders 선택한 파티션에 대해 preferred 또는 unclean 리더 선출 실행
+func ElectLeaders(c *gin.Context) {
+	var req LeaderElectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	electionType := electionTypePreferred
+	switch strings.ToLower(req.Type) {
+	case "", "preferred":
+	case "unclean":
+		if !req.ConfirmUnclean {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "unclean leader election may lose committed messages; set confirmUnclean to true to proceed",
+			})
+			return
+		}
+		if len(req.Partitions) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "unclean leader election requires an explicit partition list",
+			})
+			return
+		}
+		electionType = electionTypeUnclean
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("unknown election type %q (expected preferred or unclean)", req.Type),
+		})
+		return
+	}
+
+	partitions, err := readPartitions("")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to read partitions: %v", err),
+		})
+		return
+	}
+
+	leaders := make(map[string]int, len(partitions))
+	for _, p := range partitions {
+		leaders[partitionKey(p.Topic, p.ID)] = p.Leader.ID
+	}
+
+	targets := req.Partitions
+	if len(targets) == 0 {
+		for _, p := range findSkewedPartitions(partitions) {
+			targets = append(targets, TopicPartition{Topic: p.Topic, Partition: p.Partition})
+		}
+	}
+
+	if len(targets) == 0 {
+		c.JSON(http.StatusOK, gin.H{
+			"status":  "success",
+			"results": []LeaderElectionResult{},
+			"message": "All partitions are led by their preferred leader",
+		})
+		return
+	}
+
+	for _, t := range targets {
+		if _, ok := leaders[partitionKey(t.Topic, t.Partition)]; !ok {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": fmt.Sprintf("Partition not found: %s-%d", t.Topic, t.Partition),
+			})
+			return
+		}
+	}
+
+	resp, err := electLeaders(targets, electionType)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to elect leaders: %v", err),
+		})
+		return
+	}
+
+	// 선출 후 리더 재조회
+	newLeaders := make(map[string]int)
+	if updated, err := readPartitions(""); err == nil {
+		for _, p := range updated {
+			newLeaders[partitionKey(p.Topic, p.ID)] = p.Leader.ID
+		}
+	}
+
+	results := []LeaderElectionResult{}
+	counts := map[string]int{"elected": 0, "not_needed": 0, "failed": 0}
+	for _, r := range resp.ReplicaElectionResults {
+		for _, p := range r.PartitionResults {
+			key := partitionKey(r.Topic, int(p.PartitionID))
+			result := LeaderElectionResult{
+				Topic:     r.Topic,
+				Partition: int(p.PartitionID),
+				Status:    "elected",
+				OldLeader: leaders[key],
+				NewLeader: newLeaders[key],
+			}
+
+			switch kafka.Error(p.ErrorCode) {
+			case 0:
+			case kafka.ElectionNotNeeded:
+				result.Status = "not_needed"
+			default:
+				result.Status = "failed"
+				result.Error = kafka.Error(p.ErrorCode).Error()
+				if p.ErrorMessage != "" {
+					result.Error = p.ErrorMessage
+				}
+			}
+
+			counts[result.Status]++
+			results = append(results, result)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Topic != results[j].Topic {
+			return results[i].Topic < results[j].Topic
+		}
+		return results[i].Partition < results[j].Partition
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":     "success",
+		"type":       electionTypeName(electionType),
+		"results":    results,
+		"elected":    counts["elected"],
+		"not_needed": counts["not_needed"],
+		"failed":     counts["failed"],
+	})
+}
+
+// electLeaders ElectLeaders 요청 전송 (kafka-go 클라이언트는 선출 유형을 지원하지 않아 프로토콜 요청 사용)
+func electLeaders(targets []TopicPartition, electionType int8) (*electleaders.Response, error) {
+	byTopic := make(map[string][]int32)
+	var topics []string
+	for _, t := range targets {
+		if _, ok := byTopic[t.Topic]; !ok {
+			topics = append(topics, t.Topic)
+		}
+		byTopic[t.Topic] = append(byTopic[t.Topic], int32(t.Partition))
+	}
+
+	req := &electleaders.Request{
+		ElectionType: electionType,
+		TimeoutMs:    30000,
+	}
+	for _, topic := range topics {
+		req.TopicPartitions = append(req.TopicPartitions, electleaders.RequestTopicPartitions{
+			Topic:        topic,
+			PartitionIDs: byTopic[topic],
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	msg, err := kafka.DefaultTransport.RoundTrip(ctx, kafka.TCP(kafkaBrokers), req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := msg.(*electleaders.Response)
+	if resp.ErrorCode != 0 {
+		return nil, kafka.Error(resp.ErrorCode)
+	}
+
+	return resp, nil
+}
+
+// findSkewedPartitions 리더가 첫 번째 레플리카가 아닌 파티션 추출
+func findSkewedPartitions(partitions []kafka.Partition) []SkewedPartition {
+	skewed := []SkewedPartition{}
+	for _, p := range partitions {
+		if len(p.Replicas) == 0 || p.Leader.ID == p.Replicas[0].ID {
+			continue
+		}
+
+		isr := getReplicaIDs(p.Isr)
+		skewed = append(skewed, SkewedPartition{
+			Topic:           p.Topic,
+			Partition:       p.ID,
+			Leader:          p.Leader.ID,
+			PreferredLeader: p.Replicas[0].ID,
+			Replicas:        getReplicaIDs(p.Replicas),
+			ISR:             isr,
+			PreferredInSync: containsInt(isr, p.Replicas[0].ID),
+		})
+	}
+
+	sort.Slice(skewed, func(i, j int) bool {
+		if skewed[i].Topic != skewed[j].Topic {
+			return skewed[i].Topic < skewed[j].Topic
+		}
+		return skewed[i].Partition < skewed[j].Partition
+	})
+
+	return skewed
+}
+
+// readPartitions 파티션 메타데이터 조회 (topic이 비어 있으면 전체)
+func readPartitions(topic string) ([]kafka.Partition, error) {
+	conn, err := kafka.Dial("tcp", kafkaBrokers)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if topic == "" {
+		return conn.ReadPartitions()
+	}
+	return conn.ReadPartitions(topic)
+}
+
+// electionTypeName 선출 유형 이름
+func electionTypeName(electionType int8) string {
+	if electionType == electionTypeUnclean {
+		return "unclean"
+	}
+	return "preferred"
+}
@@ -0,0 +1,660 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	mathrand "math/rand"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"backend/auth"
+
+	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	// loadGenMaxConcurrency 작업당 최대 동시 Producer 수
+	loadGenMaxConcurrency = 64
+	// loadGenDefaultBatchSize 전송 요청 하나에 담는 기본 메시지 수
+	loadGenDefaultBatchSize = 100
+	// loadGenMaxBatchSize 전송 요청 하나에 담는 최대 메시지 수
+	loadGenMaxBatchSize = 1000
+	// loadGenMaxDuration 작업 최대 실행 시간
+	loadGenMaxDuration = 24 * time.Hour
+	// loadGenMaxRunning 동시에 실행할 수 있는 작업 수
+	loadGenMaxRunning = 5
+	// loadGenMaxFinished 조회용으로 보관하는 종료된 작업 수
+	loadGenMaxFinished = 50
+	// loadGenLatencySamples 지연 백분위 계산에 쓰는 표본 수 (reservoir sampling)
+	loadGenLatencySamples = 10000
+	// loadGenMaxErrorKinds 종류별로 집계하는 오류 메시지 수
+	loadGenMaxErrorKinds = 20
+	// loadGenWriteTimeout 전송 요청 하나의 제한 시간 (중지해도 진행 중인 요청은 끝까지 기다림)
+	loadGenWriteTimeout = 30 * time.Second
+	// loadGenRateWindow 현재 처리량 계산 구간
+	loadGenRateWindow = 5 * time.Second
+)
+
+// LoadGenRequest 부하 생성 작업 요청 (count, duration 중 하나 이상 필수)
+type LoadGenRequest struct {
+	Topic string `json:"topic" binding:"required"`
+	// Rate 목표 초당 메시지 수 (0이면 제한 없음)
+	Rate float64 `json:"rate"`
+	// Count 전송할 총 메시지 수 (0이면 duration까지)
+	Count int64 `json:"count"`
+	// Duration 최대 실행 시간 (예: 30s, 5m)
+	Duration string `json:"duration"`
+	// Concurrency 동시 Producer 수 (기본 1)
+	Concurrency int `json:"concurrency"`
+	// BatchSize 전송 요청 하나에 담는 메시지 수 (기본 100, rate가 낮으면 자동으로 줄임)
+	BatchSize int `json:"batchSize"`
+	// KeyTemplate 키 템플릿 (비우면 null 키)
+	KeyTemplate string `json:"keyTemplate"`
+	// ValueTemplate 값 템플릿
+	ValueTemplate string          `json:"valueTemplate" binding:"required"`
+	Headers       []MessageHeader `json:"headers"`
+	ProducerOptions
+}
+
+// LoadGenStatus 부하 생성 작업 상태
+type LoadGenStatus struct {
+	ID         string            `json:"id"`
+	Topic      string            `json:"topic"`
+	State      string            `json:"state"` // running, completed, stopped, failed
+	StartedBy  string            `json:"started_by,omitempty"`
+	Request    LoadGenRequest    `json:"request"`
+	StartedAt  time.Time         `json:"started_at"`
+	EndedAt    *time.Time        `json:"ended_at,omitempty"`
+	ElapsedMs  int64             `json:"elapsed_ms"`
+	Sent       int64             `json:"sent"`
+	Failed     int64             `json:"failed"`
+	Bytes      int64             `json:"bytes"`
+	Throughput LoadGenThroughput `json:"throughput"`
+	Latency    LoadGenLatency    `json:"latency"`
+	Errors     []LoadGenError    `json:"errors"`
+}
+
+// LoadGenThroughput 처리량 (전체 평균과 최근 5초)
+type LoadGenThroughput struct {
+	TargetMessagesPerSec  float64 `json:"target_messages_per_sec,omitempty"`
+	MessagesPerSec        float64 `json:"messages_per_sec"`
+	BytesPerSec           float64 `json:"bytes_per_sec"`
+	CurrentMessagesPerSec float64 `json:"current_messages_per_sec"`
+}
+
+// LoadGenLatency 전송 요청(배치) 단위 응답 지연 백분위
+type LoadGenLatency struct {
+	Requests int64   `json:"requests"`
+	P50Ms    float64 `json:"p50_ms"`
+	P90Ms    float64 `json:"p90_ms"`
+	P99Ms    float64 `json:"p99_ms"`
+	MaxMs    float64 `json:"max_ms"`
+}
+
+// LoadGenError 오류 메시지별 실패 메시지 수
+type LoadGenError struct {
+	Error string `json:"error"`
+	Count int64  `json:"count"`
+}
+
+// loadGenJob 실행 중이거나 종료된 부하 생성 작업
+type loadGenJob struct {
+	id        string
+	req       LoadGenRequest
+	startedBy string
+	startedAt time.Time
+	batchSize int
+	key       *payloadTemplate // nil이면 null 키
+	value     *payloadTemplate
+	headers   []kafka.Header
+
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	// issued 지금까지 배정한 메시지 수 (다음 시퀀스 번호)
+	issued atomic.Int64
+
+	mu         sync.Mutex
+	state      string
+	stopped    bool
+	endedAt    time.Time
+	sent       int64
+	failed     int64
+	bytes      int64
+	requests   int64
+	latencies  []time.Duration
+	maxLatency time.Duration
+	errors     map[string]int64
+	rates      []rateSample
+}
+
+// rateSample 현재 처리량 계산용 표본
+type rateSample struct {
+	at   time.Time
+	sent int64
+}
+
+// loadGenJobs 부하 생성 작업 목록 (시작 순서 유지)
+var loadGenJobs struct {
+	mu    sync.Mutex
+	byID  map[string]*loadGenJob
+	order []*loadGenJob
+}
+
+// StartLoadGen 부하 생성 작업 시작
+func StartLoadGen(c *gin.Context) {
+	var req LoadGenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var duration time.Duration
+	if req.Duration != "" {
+		d, err := time.ParseDuration(req.Duration)
+		if err != nil || d <= 0 || d > loadGenMaxDuration {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("duration must be a positive duration up to %s (e.g. 30s, 5m)", loadGenMaxDuration)})
+			return
+		}
+		duration = d
+	}
+	if req.Concurrency == 0 {
+		req.Concurrency = 1
+	}
+	if req.BatchSize == 0 {
+		req.BatchSize = loadGenDefaultBatchSize
+	}
+
+	switch {
+	case req.Count == 0 && duration == 0:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "count or duration is required"})
+		return
+	case req.Count < 0 || req.Rate < 0:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "count and rate must not be negative"})
+		return
+	case req.Concurrency < 1 || req.Concurrency > loadGenMaxConcurrency:
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("concurrency must be between 1 and %d", loadGenMaxConcurrency)})
+		return
+	case req.BatchSize < 1 || req.BatchSize > loadGenMaxBatchSize:
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("batchSize must be between 1 and %d", loadGenMaxBatchSize)})
+		return
+	case req.Idempotent:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "idempotent is not supported for load generator jobs"})
+		return
+	}
+
+	job := &loadGenJob{
+		req:       req,
+		batchSize: req.BatchSize,
+		state:     "running",
+		errors:    make(map[string]int64),
+		done:      make(chan struct{}),
+	}
+	if principal := auth.PrincipalFrom(c); principal != nil {
+		job.startedBy = principal.Name
+	}
+
+	var err error
+	if req.KeyTemplate != "" {
+		if job.key, err = newPayloadTemplate("key", req.KeyTemplate); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid keyTemplate: %v", err)})
+			return
+		}
+	}
+	if job.value, err = newPayloadTemplate("value", req.ValueTemplate); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid valueTemplate: %v", err)})
+		return
+	}
+	for _, h := range req.Headers {
+		if h.Key == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "header key is required"})
+			return
+		}
+		if h.NullValue && h.Value != "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("header %s: value must be empty when nullValue is true", h.Key)})
+			return
+		}
+		job.headers = append(job.headers, kafka.Header{Key: h.Key, Value: nullableBytes(h.Value, h.NullValue)})
+	}
+
+	// 목표 처리량이 낮으면 Producer마다 초당 10번 정도 나눠 보내도록 배치 크기를 줄임
+	if req.Rate > 0 {
+		perWorker := int(req.Rate / float64(req.Concurrency) / 10)
+		job.batchSize = max(1, min(job.batchSize, perWorker))
+	}
+
+	writers := make([]*kafka.Writer, req.Concurrency)
+	for i := range writers {
+		w, err := req.ProducerOptions.newWriter(req.Topic, nil)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		// 배치가 차거나 1ms가 지나면 바로 전송 (기본 1초 대기는 지연 측정을 왜곡함)
+		w.BatchSize = job.batchSize
+		w.BatchTimeout = time.Millisecond
+		writers[i] = w
+	}
+
+	if _, err := readPartitions(req.Topic); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to read topic partitions: %v", err)})
+		return
+	}
+
+	ctx := context.Background()
+	if duration > 0 {
+		ctx, job.cancel = context.WithTimeout(ctx, duration)
+	} else {
+		ctx, job.cancel = context.WithCancel(ctx)
+	}
+	if !registerLoadGenJob(job) {
+		job.cancel()
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": fmt.Sprintf("Too many running load generator jobs (max %d)", loadGenMaxRunning)})
+		return
+	}
+	go job.run(ctx, writers)
+
+	c.JSON(http.StatusAccepted, job.status())
+}
+
+// ListLoadGenJobs 부하 생성 작업 목록 (최근 시작 순, 조회 권한이 있는 토픽의 작업만)
+func ListLoadGenJobs(c *gin.Context) {
+	loadGenJobs.mu.Lock()
+	jobs := append([]*loadGenJob(nil), loadGenJobs.order...)
+	loadGenJobs.mu.Unlock()
+
+	principal := auth.PrincipalFrom(c)
+	statuses := make([]LoadGenStatus, 0, len(jobs))
+	for i := len(jobs) - 1; i >= 0; i-- {
+		if principal != nil && !principal.Allowed(auth.RoleViewer, jobs[i].req.Topic) {
+			continue
+		}
+		statuses = append(statuses, jobs[i].status())
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"jobs":  statuses,
+		"count": len(statuses),
+	})
+}
+
+// GetLoadGenJob 부하 생성 작업 상태 조회 (Accept: text/event-stream이면 1초마다 stats 이벤트)
+func GetLoadGenJob(c *gin.Context) {
+	job := findLoadGenJob(c.Param("id"))
+	if job == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Load generator job %s not found", c.Param("id"))})
+		return
+	}
+
+	if !strings.Contains(c.GetHeader("Accept"), "text/event-stream") {
+		c.JSON(http.StatusOK, job.status())
+		return
+	}
+
+	c.Writer.Header().Set("Content-Type", "text/event-stream")
+	c.Writer.Header().Set("Cache-Control", "no-cache")
+	c.Writer.Header().Set("Connection", "keep-alive")
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		c.SSEvent("stats", job.status())
+		c.Writer.Flush()
+
+		select {
+		case <-job.done:
+			c.SSEvent("done", job.status())
+			c.Writer.Flush()
+			return
+		case <-c.Request.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// StopLoadGenJob 부하 생성 작업 중지 (진행 중인 전송 요청이 끝날 때까지 기다린 뒤 최종 상태 반환)
+func StopLoadGenJob(c *gin.Context) {
+	job := findLoadGenJob(c.Param("id"))
+	if job == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Load generator job %s not found", c.Param("id"))})
+		return
+	}
+
+	job.mu.Lock()
+	if job.state == "running" {
+		job.stopped = true
+	}
+	job.mu.Unlock()
+	job.cancel()
+	<-job.done
+
+	c.JSON(http.StatusOK, job.status())
+}
+
+// LoadGenJobTopic 경로의 작업 ID로 작업 대상 토픽 조회 (토픽별 권한 검사용)
+func LoadGenJobTopic(param string) auth.TopicSource {
+	return func(c *gin.Context) string {
+		if job := findLoadGenJob(c.Param(param)); job != nil {
+			return job.req.Topic
+		}
+		return ""
+	}
+}
+
+// registerLoadGenJob 작업 등록 (실행 중인 작업이 너무 많으면 false, 오래된 종료 작업 정리)
+func registerLoadGenJob(job *loadGenJob) bool {
+	loadGenJobs.mu.Lock()
+	defer loadGenJobs.mu.Unlock()
+
+	running, finished := 0, 0
+	for _, j := range loadGenJobs.order {
+		if j.running() {
+			running++
+		} else {
+			finished++
+		}
+	}
+	if running >= loadGenMaxRunning {
+		return false
+	}
+
+	kept := loadGenJobs.order[:0]
+	for _, j := range loadGenJobs.order {
+		if finished >= loadGenMaxFinished && !j.running() {
+			delete(loadGenJobs.byID, j.id)
+			finished--
+			continue
+		}
+		kept = append(kept, j)
+	}
+
+	if loadGenJobs.byID == nil {
+		loadGenJobs.byID = make(map[string]*loadGenJob)
+	}
+	job.id = newJobID()
+	job.startedAt = time.Now()
+	loadGenJobs.byID[job.id] = job
+	loadGenJobs.order = append(kept, job)
+	return true
+}
+
+// findLoadGenJob ID로 작업 조회
+func findLoadGenJob(id string) *loadGenJob {
+	loadGenJobs.mu.Lock()
+	defer loadGenJobs.mu.Unlock()
+	return loadGenJobs.byID[id]
+}
+
+// newJobID 백그라운드 작업 ID 생성
+func newJobID() string {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%012x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// run Producer를 concurrency개 띄우고 모두 끝나면 최종 상태 기록
+func (j *loadGenJob) run(ctx context.Context, writers []*kafka.Writer) {
+	defer close(j.done)
+	defer j.cancel()
+
+	var wg sync.WaitGroup
+	for i, w := range writers {
+		wg.Add(1)
+		go func(worker int, w *kafka.Writer) {
+			defer wg.Done()
+			defer w.Close()
+			j.work(ctx, worker, w)
+		}(i, w)
+	}
+
+	finished := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for waiting := true; waiting; {
+		select {
+		case <-ticker.C:
+			j.sampleRate()
+		case <-finished:
+			waiting = false
+		}
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.endedAt = time.Now()
+	switch {
+	case j.stopped:
+		j.state = "stopped"
+	case j.sent == 0 && j.failed > 0:
+		j.state = "failed"
+	default:
+		j.state = "completed"
+	}
+}
+
+// work Producer 하나의 전송 루프
+func (j *loadGenJob) work(ctx context.Context, worker int, w *kafka.Writer) {
+	for ctx.Err() == nil {
+		first, n := j.claim()
+		if n == 0 {
+			return
+		}
+
+		// 목표 처리량에 맞춰 첫 메시지의 예정 시각까지 대기
+		if j.req.Rate > 0 {
+			due := j.startedAt.Add(time.Duration(float64(first) / j.req.Rate * float64(time.Second)))
+			if d := time.Until(due); d > 0 {
+				timer := time.NewTimer(d)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				case <-timer.C:
+				}
+			}
+		}
+
+		messages, err := j.messages(worker, first, n)
+		if err != nil {
+			j.record(nil, 0, fmt.Errorf("render template: %v", err), n)
+			continue
+		}
+
+		writeCtx, cancel := context.WithTimeout(context.Background(), loadGenWriteTimeout)
+		start := time.Now()
+		err = w.WriteMessages(writeCtx, messages...)
+		cancel()
+		j.record(messages, time.Since(start), err, n)
+	}
+}
+
+// claim 보낼 메시지 시퀀스 구간 배정 (count에 도달하면 0개)
+func (j *loadGenJob) claim() (int64, int) {
+	n := int64(j.batchSize)
+	end := j.issued.Add(n)
+	first := end - n
+	if j.req.Count > 0 {
+		if first >= j.req.Count {
+			return 0, 0
+		}
+		if end > j.req.Count {
+			n = j.req.Count - first
+		}
+	}
+	return first, int(n)
+}
+
+// messages 템플릿으로 시퀀스 first부터 n개 메시지 생성
+func (j *loadGenJob) messages(worker int, first int64, n int) ([]kafka.Message, error) {
+	messages := make([]kafka.Message, n)
+	for i := range messages {
+		data := payloadData{Seq: first + int64(i), Worker: worker}
+		msg := kafka.Message{Headers: j.headers}
+		if j.key != nil {
+			key, err := j.key.render(data)
+			if err != nil {
+				return nil, err
+			}
+			msg.Key = key
+		}
+		value, err := j.value.render(data)
+		if err != nil {
+			return nil, err
+		}
+		msg.Value = value
+		if j.req.Timestamp != nil {
+			msg.Time = *j.req.Timestamp
+		}
+		messages[i] = msg
+	}
+	return messages, nil
+}
+
+// record 전송 요청 결과 집계 (kafka.WriteErrors면 메시지별로 성공/실패 구분)
+func (j *loadGenJob) record(messages []kafka.Message, latency time.Duration, err error, n int) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var writeErrs kafka.WriteErrors
+	sent := 0
+	switch {
+	case err == nil:
+		for _, m := range messages {
+			j.bytes += int64(len(m.Key) + len(m.Value))
+		}
+		sent = len(messages)
+	case errors.As(err, &writeErrs) && len(writeErrs) == len(messages):
+		for i, e := range writeErrs {
+			if e != nil {
+				j.addError(e, 1)
+				continue
+			}
+			j.bytes += int64(len(messages[i].Key) + len(messages[i].Value))
+			sent++
+		}
+	default:
+		j.addError(err, n)
+	}
+	j.sent += int64(sent)
+	if sent == 0 {
+		return // 전부 실패한 요청(타임아웃 등)은 지연 통계에서 제외
+	}
+
+	// reservoir sampling으로 표본 수를 제한하면서 전체 구간을 고르게 반영
+	j.requests++
+	if len(j.latencies) < loadGenLatencySamples {
+		j.latencies = append(j.latencies, latency)
+	} else if r := mathrand.Int63n(j.requests); r < loadGenLatencySamples {
+		j.latencies[r] = latency
+	}
+	if latency > j.maxLatency {
+		j.maxLatency = latency
+	}
+}
+
+// addError 오류 메시지별 실패 수 집계 (종류가 너무 많으면 other로 묶음)
+func (j *loadGenJob) addError(err error, n int) {
+	j.failed += int64(n)
+	msg := err.Error()
+	if _, ok := j.errors[msg]; !ok && len(j.errors) >= loadGenMaxErrorKinds {
+		msg = "other"
+	}
+	j.errors[msg] += int64(n)
+}
+
+// sampleRate 현재 처리량 계산용 표본 추가 (최근 5초만 유지)
+func (j *loadGenJob) sampleRate() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := time.Now()
+	j.rates = append(j.rates, rateSample{at: now, sent: j.sent})
+	for len(j.rates) > 1 && now.Sub(j.rates[0].at) > loadGenRateWindow {
+		j.rates = j.rates[1:]
+	}
+}
+
+// running 실행 중 여부
+func (j *loadGenJob) running() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.state == "running"
+}
+
+// status 현재 상태 스냅샷
+func (j *loadGenJob) status() LoadGenStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	end := time.Now()
+	s := LoadGenStatus{
+		ID:        j.id,
+		Topic:     j.req.Topic,
+		State:     j.state,
+		StartedBy: j.startedBy,
+		Request:   j.req,
+		StartedAt: j.startedAt,
+		Sent:      j.sent,
+		Failed:    j.failed,
+		Bytes:     j.bytes,
+		Errors:    make([]LoadGenError, 0, len(j.errors)),
+	}
+	if !j.endedAt.IsZero() {
+		end = j.endedAt
+		endedAt := j.endedAt
+		s.EndedAt = &endedAt
+	}
+	elapsed := end.Sub(j.startedAt)
+	s.ElapsedMs = elapsed.Milliseconds()
+
+	s.Throughput.TargetMessagesPerSec = j.req.Rate
+	if secs := elapsed.Seconds(); secs > 0 {
+		s.Throughput.MessagesPerSec = float64(j.sent) / secs
+		s.Throughput.BytesPerSec = float64(j.bytes) / secs
+	}
+	if len(j.rates) > 0 && j.state == "running" {
+		oldest := j.rates[0]
+		if secs := end.Sub(oldest.at).Seconds(); secs > 0 {
+			s.Throughput.CurrentMessagesPerSec = float64(j.sent-oldest.sent) / secs
+		}
+	}
+
+	s.Latency = LoadGenLatency{Requests: j.requests, MaxMs: durationMs(j.maxLatency)}
+	if len(j.latencies) > 0 {
+		sorted := append([]time.Duration(nil), j.latencies...)
+		sort.Slice(sorted, func(a, b int) bool { return sorted[a] < sorted[b] })
+		percentile := func(p float64) float64 {
+			return durationMs(sorted[int(math.Ceil(p*float64(len(sorted))))-1]) // nearest-rank
+		}
+		s.Latency.P50Ms = percentile(0.50)
+		s.Latency.P90Ms = percentile(0.90)
+		s.Latency.P99Ms = percentile(0.99)
+	}
+
+	for msg, count := range j.errors {
+		s.Errors = append(s.Errors, LoadGenError{Error: msg, Count: count})
+	}
+	sort.Slice(s.Errors, func(a, b int) bool { return s.Errors[a].Count > s.Errors[b].Count })
+	return s
+}
+
+// durationMs 밀리초 (소수점 둘째 자리까지)
+func durationMs(d time.Duration) float64 {
+	return float64(d.Microseconds()/10) / 100
+}
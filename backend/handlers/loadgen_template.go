@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// payloadData 메시지별 템플릿 데이터 ({{.Seq}}, {{.Worker}})
+type payloadData struct {
+	Seq    int64
+	Worker int
+}
+
+// payloadTemplate 부하 생성 키/값 템플릿
+//
+// text/template 문법을 사용하며 {{seq}}는 {{.Seq}}와 같다. 사용할 수 있는 함수는
+// payloadFuncs 참고.
+type payloadTemplate struct {
+	tmpl *template.Template
+}
+
+// seqPlaceholder {{seq}} 자리표시자
+var seqPlaceholder = regexp.MustCompile(`{{-?\s*seq\s*-?}}`)
+
+// newPayloadTemplate 템플릿 파싱 후 한 번 실행해 인자 오류를 미리 확인
+func newPayloadTemplate(name, text string) (*payloadTemplate, error) {
+	text = seqPlaceholder.ReplaceAllString(text, "{{.Seq}}")
+	tmpl, err := template.New(name).Option("missingkey=error").Funcs(payloadFuncs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	t := &payloadTemplate{tmpl: tmpl}
+	if _, err := t.render(payloadData{}); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// render 메시지 하나의 키 또는 값 생성
+func (t *payloadTemplate) render(data payloadData) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// payloadFuncs 템플릿 함수 (math/rand 기본 소스는 여러 goroutine에서 안전)
+var payloadFuncs = template.FuncMap{
+	"uuid": randomUUID,
+	"randInt": func(min, max int) (int, error) {
+		if max < min {
+			return 0, fmt.Errorf("randInt: max %d is less than min %d", max, min)
+		}
+		return min + rand.Intn(max-min+1), nil
+	},
+	"randFloat": func(min, max float64) string {
+		return strconv.FormatFloat(min+rand.Float64()*(max-min), 'f', 2, 64)
+	},
+	"randString": func(n int) (string, error) {
+		if n < 0 || n > 1<<20 {
+			return "", fmt.Errorf("randString: length must be between 0 and %d", 1<<20)
+		}
+		return randomString(n), nil
+	},
+	"randBool": func() bool { return rand.Intn(2) == 0 },
+	"pick": func(choices ...string) (string, error) {
+		if len(choices) == 0 {
+			return "", fmt.Errorf("pick: at least one choice is required")
+		}
+		return choices[rand.Intn(len(choices))], nil
+	},
+	"timestamp": func() string { return time.Now().UTC().Format(time.RFC3339Nano) },
+	"unixMs":    func() int64 { return time.Now().UnixMilli() },
+
+	// faker 스타일 필드
+	"firstName": func() string { return pickOne(fakeFirstNames) },
+	"lastName":  func() string { return pickOne(fakeLastNames) },
+	"name":      func() string { return pickOne(fakeFirstNames) + " " + pickOne(fakeLastNames) },
+	"email": func() string {
+		return strings.ToLower(pickOne(fakeFirstNames)) + "." + strings.ToLower(pickOne(fakeLastNames)) +
+			strconv.Itoa(rand.Intn(1000)) + "@" + pickOne(fakeDomains)
+	},
+	"city":    func() string { return pickOne(fakeCities) },
+	"country": func() string { return pickOne(fakeCountries) },
+	"word":    func() string { return pickOne(fakeWords) },
+	"sentence": func() string {
+		words := make([]string, 4+rand.Intn(6))
+		for i := range words {
+			words[i] = pickOne(fakeWords)
+		}
+		s := strings.Join(words, " ")
+		return strings.ToUpper(s[:1]) + s[1:] + "."
+	},
+	"ipv4": func() string {
+		return fmt.Sprintf("%d.%d.%d.%d", 1+rand.Intn(223), rand.Intn(256), rand.Intn(256), 1+rand.Intn(254))
+	},
+	"phone": func() string {
+		return fmt.Sprintf("010-%04d-%04d", rand.Intn(10000), rand.Intn(10000))
+	},
+}
+
+// randomUUID 무작위 UUID (version 4)
+func randomUUID() string {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], rand.Uint64())
+	binary.BigEndian.PutUint64(b[8:], rand.Uint64())
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+const randomStringChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// randomString 영문자와 숫자로 된 길이 n 문자열
+func randomString(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = randomStringChars[rand.Intn(len(randomStringChars))]
+	}
+	return string(b)
+}
+
+func pickOne(list []string) string {
+	return list[rand.Intn(len(list))]
+}
+
+var (
+	fakeFirstNames = []string{"James", "Mary", "John", "Patricia", "Robert", "Jennifer", "Michael", "Linda", "David", "Elizabeth", "Minjun", "Seoyeon", "Jiho", "Haeun", "Hiroshi", "Yuki", "Wei", "Li", "Carlos", "Sofia"}
+	fakeLastNames  = []string{"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis", "Kim", "Lee", "Park", "Choi", "Tanaka", "Suzuki", "Wang", "Zhang", "Martinez", "Lopez", "Muller", "Rossi"}
+	fakeDomains    = []string{"example.com", "example.org", "example.net", "test.io", "mail.test"}
+	fakeCities     = []string{"Seoul", "Busan", "Tokyo", "Osaka", "Shanghai", "Singapore", "Sydney", "London", "Paris", "Berlin", "Madrid", "New York", "Chicago", "Toronto", "Sao Paulo", "Mumbai"}
+	fakeCountries  = []string{"KR", "JP", "CN", "SG", "AU", "GB", "FR", "DE", "ES", "US", "CA", "BR", "IN"}
+	fakeWords      = []string{"alpha", "bravo", "charlie", "delta", "echo", "order", "payment", "shipment", "invoice", "customer", "account", "event", "stream", "cluster", "partition", "record", "update", "create", "delete", "status"}
+)
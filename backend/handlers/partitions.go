@@ -0,0 +1,298 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	// keySamplePerPartition 파티션별로 샘플링할 최근 메시지 수
+	keySamplePerPartition = 50
+	// maxRemappedKeySamples 응답에 포함할 재배치 키 샘플 최대 수
+	maxRemappedKeySamples = 20
+)
+
+// IncreasePartitionsRequest 파티션 증가 요청
+type IncreasePartitionsRequest struct {
+	Count int `json:"count" binding:"required,min=1"`
+	// Assignments 새로 추가되는 파티션별 레플리카 브로커 ID (선택사항, 첫 번째가 선호 리더)
+	Assignments [][]int `json:"assignments"`
+	DryRun      bool    `json:"dryRun"`
+}
+
+// KeyRemapping 파티션 수 변경에 따른 키 재배치 분석 결과
+type KeyRemapping struct {
+	Balancer          string        `json:"balancer"`
+	OldPartitionCount int           `json:"old_partition_count"`
+	NewPartitionCount int           `json:"new_partition_count"`
+	SampledKeys       int           `json:"sampled_keys"`
+	RemappedKeys      int           `json:"remapped_keys"`
+	RemappedRatio     float64       `json:"remapped_ratio"`
+	Samples           []RemappedKey `json:"samples"`
+}
+
+// RemappedKey 파티션이 바뀌는 키 샘플
+type RemappedKey struct {
+	Key          string `json:"key"`
+	OldPartition int    `json:"old_partition"`
+	NewPartition int    `json:"new_partition"`
+}
+
+// IncreasePartitions 기존 토픽의 파티션 수 증가
+func IncreasePartitions(c *gin.Context) {
+	topicName := c.Param("name")
+
+	var req IncreasePartitionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	conn, err := kafka.Dial("tcp", kafkaBrokers)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to connect to Kafka: %v", err),
+		})
+		return
+	}
+	defer conn.Close()
+
+	partitions, err := conn.ReadPartitions(topicName)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to read partitions: %v", err),
+		})
+		return
+	}
+
+	if len(partitions) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Topic not found",
+		})
+		return
+	}
+
+	currentCount := len(partitions)
+	if req.Count <= currentCount {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("count must be greater than the current partition count (%d)", currentCount),
+		})
+		return
+	}
+
+	if violations := topicPolicy.checkPartitions(req.Count); len(violations) > 0 {
+		respondPolicyViolations(c, violations)
+		return
+	}
+
+	assignments, err := buildPartitionAssignments(req.Assignments, req.Count-currentCount)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	client := newKafkaClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	resp, err := client.CreatePartitions(ctx, &kafka.CreatePartitionsRequest{
+		Topics: []kafka.TopicPartitionsConfig{
+			{
+				Name:                      topicName,
+				Count:                     int32(req.Count),
+				TopicPartitionAssignments: assignments,
+			},
+		},
+		ValidateOnly: req.DryRun,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to create partitions: %v", err),
+		})
+		return
+	}
+
+	if err := resp.Errors[topicName]; err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("Failed to create partitions: %v", err),
+		})
+		return
+	}
+
+	remapping := analyzeKeyRemapping(topicName, partitions, req.Count)
+
+	status := "success"
+	message := "Partitions added successfully"
+	if req.DryRun {
+		status = "validated"
+		message = "Partition increase is valid (dry run, nothing applied)"
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":              status,
+		"topic":               topicName,
+		"dry_run":             req.DryRun,
+		"old_partition_count": currentCount,
+		"new_partition_count": req.Count,
+		"message":             message,
+		"warning":             keyRemappingWarning(remapping),
+		"key_remapping":       remapping,
+	})
+}
+
+// keyRemappingWarning 키 재배치 경고 (샘플링한 키가 없으면 비율 대신 그 사실을 알림)
+func keyRemappingWarning(remapping KeyRemapping) string {
+	const prefix = "Key-based partitioning (kafka.Hash) maps keys by hash % partition count; "
+	if remapping.SampledKeys == 0 {
+		return prefix + "no keyed messages were sampled, so the share of keys that will move is unknown. " +
+			"Per-key ordering is not guaranteed across the change for any keys produced to this topic"
+	}
+	return prefix + fmt.Sprintf(
+		"about %.0f%% of %d sampled keys will move to a different partition, "+
+			"so per-key ordering is not guaranteed across the change",
+		remapping.RemappedRatio*100, remapping.SampledKeys)
+}
+
+// buildPartitionAssignments 요청의 레플리카 배치를 검증하고 kafka 형식으로 변환
+func buildPartitionAssignments(assignments [][]int, added int) ([]kafka.TopicPartitionAssignment, error) {
+	if len(assignments) == 0 {
+		return nil, nil
+	}
+
+	if len(assignments) != added {
+		return nil, fmt.Errorf("assignments must have one entry per new partition (expected %d, got %d)", added, len(assignments))
+	}
+
+	replicationFactor := len(assignments[0])
+	result := make([]kafka.TopicPartitionAssignment, 0, len(assignments))
+
+	for i, brokerIDs := range assignments {
+		if len(brokerIDs) == 0 {
+			return nil, fmt.Errorf("assignments[%d] must not be empty", i)
+		}
+		if len(brokerIDs) != replicationFactor {
+			return nil, errors.New("all assignments must have the same number of replicas")
+		}
+
+		seen := make(map[int]bool)
+		ids := make([]int32, 0, len(brokerIDs))
+		for _, id := range brokerIDs {
+			if seen[id] {
+				return nil, fmt.Errorf("assignments[%d] contains broker %d more than once", i, id)
+			}
+			seen[id] = true
+			ids = append(ids, int32(id))
+		}
+
+		result = append(result, kafka.TopicPartitionAssignment{BrokerIDs: ids})
+	}
+
+	return result, nil
+}
+
+// analyzeKeyRemapping 최근 메시지 키를 샘플링해 파티션 수 변경 시 재배치되는 비율 계산
+func analyzeKeyRemapping(topic string, partitions []kafka.Partition, newCount int) KeyRemapping {
+	oldCount := len(partitions)
+	result := KeyRemapping{
+		Balancer:          "hash (FNV-1a, kafka.Hash)",
+		OldPartitionCount: oldCount,
+		NewPartitionCount: newCount,
+		Samples:           []RemappedKey{},
+	}
+
+	oldPartitions := partitionRange(oldCount)
+	newPartitions := partitionRange(newCount)
+	balancer := &kafka.Hash{}
+
+	seen := make(map[string]bool)
+	for _, p := range partitions {
+		for _, key := range sampleRecentKeys(topic, p.ID, keySamplePerPartition) {
+			if seen[string(key)] {
+				continue
+			}
+			seen[string(key)] = true
+			result.SampledKeys++
+
+			msg := kafka.Message{Key: key}
+			oldPartition := balancer.Balance(msg, oldPartitions...)
+			newPartition := balancer.Balance(msg, newPartitions...)
+			if oldPartition == newPartition {
+				continue
+			}
+
+			result.RemappedKeys++
+			if len(result.Samples) < maxRemappedKeySamples {
+				result.Samples = append(result.Samples, RemappedKey{
+					Key:          string(key),
+					OldPartition: oldPartition,
+					NewPartition: newPartition,
+				})
+			}
+		}
+	}
+
+	if result.SampledKeys > 0 {
+		result.RemappedRatio = float64(result.RemappedKeys) / float64(result.SampledKeys)
+	}
+
+	return result
+}
+
+// sampleRecentKeys 파티션의 최근 메시지에서 키 목록 수집 (null/빈 키 제외)
+func sampleRecentKeys(topic string, partition int, limit int) [][]byte {
+	first, last := getPartitionOffsets(topic, partition)
+	if first < 0 || last <= first {
+		return nil
+	}
+
+	start := last - int64(limit)
+	if start < first {
+		start = first
+	}
+
+	conn, err := kafka.DialLeader(context.Background(), "tcp", kafkaBrokers, topic, partition)
+	if err != nil {
+		return nil
+	}
+	defer conn.Close()
+
+	if _, err := conn.Seek(start, kafka.SeekAbsolute); err != nil {
+		return nil
+	}
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	batch := conn.ReadBatch(1, 10e6)
+	defer batch.Close()
+
+	var keys [][]byte
+	for len(keys) < limit {
+		msg, err := batch.ReadMessage()
+		if err != nil {
+			break
+		}
+		if len(msg.Key) > 0 {
+			keys = append(keys, msg.Key)
+		}
+		if msg.Offset >= last-1 {
+			break
+		}
+	}
+
+	return keys
+}
+
+// partitionRange 0부터 n-1까지의 파티션 ID 목록
+func partitionRange(n int) []int {
+	ids := make([]int, n)
+	for i := range ids {
+		ids[i] = i
+	}
+	return ids
+}
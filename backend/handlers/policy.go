@@ -0,0 +1,301 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
+)
+
+// topicPolicy 토픽 정책 (nil이면 정책 미적용, 시작 시 한 번 로드)
+var topicPolicy *TopicPolicy
+
+// TopicPolicy 토픽 생성/설정 변경 정책
+type TopicPolicy struct {
+	// NamePattern 토픽 이름 정규식 (이름 전체와 일치해야 함)
+	NamePattern string `json:"namePattern,omitempty"`
+	// AllowedPrefixes 허용 접두사 (지정 시 하나 이상과 일치해야 함)
+	AllowedPrefixes      []string `json:"allowedPrefixes,omitempty"`
+	MinPartitions        int      `json:"minPartitions,omitempty"`
+	MaxPartitions        int      `json:"maxPartitions,omitempty"`
+	MinReplicationFactor int      `json:"minReplicationFactor,omitempty"`
+	// MinInsyncReplicas 토픽에 적용되는 min.insync.replicas 최소값 (토픽에 없으면 브로커 기본값으로 판단)
+	MinInsyncReplicas int `json:"minInsyncReplicas,omitempty"`
+	// RequiredConfigs 토픽에 명시적으로 지정해야 하는 설정
+	RequiredConfigs []string `json:"requiredConfigs,omitempty"`
+	// MaxConfigValues 숫자형 설정 상한 (예: retention.ms), 음수(무제한)도 위반
+	MaxConfigValues map[string]int64 `json:"maxConfigValues,omitempty"`
+	// ProtectedTopics 삭제할 수 없는 토픽 이름 정규식 (내부 토픽은 항상 보호)
+	ProtectedTopics []string `json:"protectedTopics,omitempty"`
+	// ArchivePrefix soft 삭제 시 보관 토픽 이름 접두사 (기본값 archive.)
+	ArchivePrefix string `json:"archivePrefix,omitempty"`
+
+	nameRegexp       *regexp.Regexp
+	protectedRegexps []*regexp.Regexp
+}
+
+// PolicyViolation 정책 위반 항목
+type PolicyViolation struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// LoadTopicPolicy YAML/JSON 정책 파일 로드 (TOPIC_POLICY_FILE)
+func LoadTopicPolicy(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var policy TopicPolicy
+	if err := decodeYAMLOrJSON(data, &policy); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	if policy.NamePattern != "" {
+		re, err := regexp.Compile("^(?:" + policy.NamePattern + ")$")
+		if err != nil {
+			return fmt.Errorf("namePattern: %w", err)
+		}
+		policy.nameRegexp = re
+	}
+
+	for _, pattern := range policy.ProtectedTopics {
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			return fmt.Errorf("protectedTopics: %w", err)
+		}
+		policy.protectedRegexps = append(policy.protectedRegexps, re)
+	}
+
+	if policy.MaxPartitions > 0 && policy.MinPartitions > policy.MaxPartitions {
+		return fmt.Errorf("minPartitions (%d) is greater than maxPartitions (%d)", policy.MinPartitions, policy.MaxPartitions)
+	}
+
+	topicPolicy = &policy
+	return nil
+}
+
+// GetTopicPolicy 현재 적용 중인 토픽 정책 조회
+func GetTopicPolicy(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"enabled": topicPolicy != nil,
+		"policy":  topicPolicy,
+	})
+}
+
+// checkTopic 토픽 이름, 파티션 수, 복제 계수 검사 (토픽 생성 시)
+func (p *TopicPolicy) checkTopic(name string, partitions, replicationFactor int) []PolicyViolation {
+	if p == nil {
+		return nil
+	}
+
+	var violations []PolicyViolation
+	if p.nameRegexp != nil && !p.nameRegexp.MatchString(name) {
+		violations = append(violations, PolicyViolation{
+			Field:   "name",
+			Rule:    "namePattern",
+			Message: fmt.Sprintf("topic name %q does not match %s", name, p.NamePattern),
+		})
+	}
+
+	if len(p.AllowedPrefixes) > 0 {
+		allowed := false
+		for _, prefix := range p.AllowedPrefixes {
+			if strings.HasPrefix(name, prefix) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			violations = append(violations, PolicyViolation{
+				Field:   "name",
+				Rule:    "allowedPrefixes",
+				Message: fmt.Sprintf("topic name %q must start with one of %s", name, strings.Join(p.AllowedPrefixes, ", ")),
+			})
+		}
+	}
+
+	violations = append(violations, p.checkPartitions(partitions)...)
+
+	if p.MinReplicationFactor > 0 && replicationFactor < p.MinReplicationFactor {
+		violations = append(violations, PolicyViolation{
+			Field:   "replicationFactor",
+			Rule:    "minReplicationFactor",
+			Message: fmt.Sprintf("replication factor %d is below the minimum %d", replicationFactor, p.MinReplicationFactor),
+		})
+	}
+
+	return violations
+}
+
+// checkPartitions 파티션 수 범위 검사 (생성 및 파티션 증가 시)
+func (p *TopicPolicy) checkPartitions(partitions int) []PolicyViolation {
+	if p == nil {
+		return nil
+	}
+
+	var violations []PolicyViolation
+	if p.MinPartitions > 0 && partitions < p.MinPartitions {
+		violations = append(violations, PolicyViolation{
+			Field:   "partitions",
+			Rule:    "minPartitions",
+			Message: fmt.Sprintf("partition count %d is below the minimum %d", partitions, p.MinPartitions),
+		})
+	}
+	if p.MaxPartitions > 0 && partitions > p.MaxPartitions {
+		violations = append(violations, PolicyViolation{
+			Field:   "partitions",
+			Rule:    "maxPartitions",
+			Message: fmt.Sprintf("partition count %d exceeds the maximum %d", partitions, p.MaxPartitions),
+		})
+	}
+	return violations
+}
+
+// checkConfigs 토픽에 지정된 설정(변경 후 기준) 검사
+func (p *TopicPolicy) checkConfigs(replicationFactor int, configs map[string]string) ([]PolicyViolation, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	var violations []PolicyViolation
+	for _, name := range p.RequiredConfigs {
+		if _, ok := configs[name]; !ok {
+			violations = append(violations, PolicyViolation{
+				Field:   "configs." + name,
+				Rule:    "requiredConfigs",
+				Message: fmt.Sprintf("config %s must be set on the topic", name),
+			})
+		}
+	}
+
+	if p.MinInsyncReplicas > 0 {
+		value, ok := configs["min.insync.replicas"]
+		if !ok {
+			var err error
+			if value, err = clusterMinInsyncReplicas(); err != nil {
+				return nil, err
+			}
+		}
+
+		minISR, err := strconv.Atoi(value)
+		switch {
+		case err != nil:
+			violations = append(violations, PolicyViolation{
+				Field:   "configs.min.insync.replicas",
+				Rule:    "minInsyncReplicas",
+				Message: fmt.Sprintf("min.insync.replicas %q is not a number", value),
+			})
+		case minISR < p.MinInsyncReplicas:
+			violations = append(violations, PolicyViolation{
+				Field:   "configs.min.insync.replicas",
+				Rule:    "minInsyncReplicas",
+				Message: fmt.Sprintf("min.insync.replicas %d is below the minimum %d", minISR, p.MinInsyncReplicas),
+			})
+		case replicationFactor > 0 && minISR > replicationFactor:
+			violations = append(violations, PolicyViolation{
+				Field:   "configs.min.insync.replicas",
+				Rule:    "minInsyncReplicas",
+				Message: fmt.Sprintf("min.insync.replicas %d exceeds the replication factor %d", minISR, replicationFactor),
+			})
+		}
+	}
+
+	names := make([]string, 0, len(p.MaxConfigValues))
+	for name := range p.MaxConfigValues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value, ok := configs[name]
+		if !ok {
+			continue
+		}
+		limit := p.MaxConfigValues[name]
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n < 0 || n > limit {
+			violations = append(violations, PolicyViolation{
+				Field:   "configs." + name,
+				Rule:    "maxConfigValues",
+				Message: fmt.Sprintf("%s=%s exceeds the maximum %d", name, value, limit),
+			})
+		}
+	}
+
+	return violations, nil
+}
+
+// clusterMinInsyncReplicas 토픽에 지정되지 않았을 때 적용되는 브로커 min.insync.replicas
+func clusterMinInsyncReplicas() (string, error) {
+	conn, err := kafka.Dial("tcp", kafkaBrokers)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	controller, err := conn.Controller()
+	if err != nil {
+		return "", err
+	}
+
+	entries, err := describeConfigs(kafka.ResourceTypeBroker, strconv.Itoa(controller.ID))
+	if err != nil {
+		return "", err
+	}
+
+	for _, e := range entries {
+		if e.Name == "min.insync.replicas" {
+			return e.Value, nil
+		}
+	}
+	return "1", nil
+}
+
+// topicConfigsAfter 변경 적용 후 토픽에 직접 지정된 설정 계산
+func topicConfigsAfter(current []ConfigEntry, previews []ConfigChangePreview) map[string]string {
+	configs := make(map[string]string)
+	for _, e := range current {
+		if e.Source == "dynamic_topic" {
+			configs[e.Name] = e.Value
+		}
+	}
+	for _, p := range previews {
+		if p.NewValue == nil {
+			delete(configs, p.Name)
+			continue
+		}
+		configs[p.Name] = *p.NewValue
+	}
+	return configs
+}
+
+// policyViolationError 대시보드가 직접 만드는 토픽(보관 토픽, 카나리 토픽 등)이 정책을 위반할 때 반환하는 오류
+type policyViolationError struct {
+	topic      string
+	violations []PolicyViolation
+}
+
+func (e *policyViolationError) Error() string {
+	messages := make([]string, 0, len(e.violations))
+	for _, v := range e.violations {
+		messages = append(messages, v.Message)
+	}
+	return fmt.Sprintf("topic %s violates the topic policy: %s", e.topic, strings.Join(messages, "; "))
+}
+
+// respondPolicyViolations 정책 위반 응답
+func respondPolicyViolations(c *gin.Context, violations []PolicyViolation) {
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error":      "Topic policy violation",
+		"violations": violations,
+	})
+}
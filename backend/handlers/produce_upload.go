@@ -0,0 +1,597 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	// uploadDefaultChunkSize 한 번에 전송하는 기본 메시지 수
+	uploadDefaultChunkSize = 500
+	// uploadMaxChunkSize 청크 크기 상한
+	uploadMaxChunkSize = 10000
+	// uploadMaxRecordBytes 한 레코드(줄) 최대 크기
+	uploadMaxRecordBytes = 10 << 20
+	// uploadMaxErrors 요약에 포함할 레코드 오류 최대 개수
+	uploadMaxErrors = 100
+	// uploadChunkTimeout 청크 하나의 전송 제한 시간
+	uploadChunkTimeout = 30 * time.Second
+)
+
+// UploadRecordError 업로드 파일에서 실패한 레코드
+type UploadRecordError struct {
+	Record int    `json:"record"` // 1부터 시작하는 레코드 번호 (CSV 헤더 행 제외)
+	Error  string `json:"error"`
+}
+
+// OffsetRange 파티션별로 기록된 오프셋 범위
+type OffsetRange struct {
+	Partition   int   `json:"partition"`
+	FirstOffset int64 `json:"first_offset"`
+	LastOffset  int64 `json:"last_offset"`
+	Count       int   `json:"count"`
+}
+
+// UploadSummary 파일 업로드 전송 진행 상황 및 최종 요약
+type UploadSummary struct {
+	Topic           string              `json:"topic"`
+	Format          string              `json:"format"`
+	Records         int                 `json:"records"`
+	Sent            int                 `json:"sent"`
+	Failed          int                 `json:"failed"`
+	BytesRead       int64               `json:"bytes_read"`
+	OffsetRanges    []OffsetRange       `json:"offset_ranges"`
+	Errors          []UploadRecordError `json:"errors,omitempty"`
+	ErrorsTruncated bool                `json:"errors_truncated,omitempty"`
+	DurationMs      int64               `json:"duration_ms"`
+
+	ranges map[int]*OffsetRange
+}
+
+// UploadProduceMessages 업로드한 파일(NDJSON, CSV, kcat, 백업 형식)을 청크 단위로 스트리밍 전송
+//
+// 옵션은 쿼리 파라미터로 받고(권한 검사와 감사 기록이 본문을 읽지 않도록) 파일은
+// multipart의 file 필드로 받는다. Accept: text/event-stream이면 청크마다 progress
+// 이벤트를, 끝나면 summary 이벤트를 보내고, 아니면 요약을 JSON으로 응답한다.
+func UploadProduceMessages(c *gin.Context) {
+	query := c.Request.URL.Query()
+	topic := query.Get("topic")
+	if topic == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "topic is required"})
+		return
+	}
+
+	chunkSize := uploadDefaultChunkSize
+	if v := query.Get("chunkSize"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > uploadMaxChunkSize {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("chunkSize must be between 1 and %d", uploadMaxChunkSize)})
+			return
+		}
+		chunkSize = n
+	}
+
+	opts := ProducerOptions{
+		Balancer:    query.Get("balancer"),
+		Acks:        query.Get("acks"),
+		Compression: query.Get("compression"),
+	}
+	if v := query.Get("timestamp"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid timestamp (expected RFC3339): %v", err)})
+			return
+		}
+		opts.Timestamp = &t
+	}
+
+	fixed := make(map[int]int)
+	writer, err := opts.newWriter(topic, fixed)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer writer.Close()
+
+	partitions, err := readPartitions(topic)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to read topic partitions: %v", err)})
+		return
+	}
+	partitionIDs := make(map[int]bool, len(partitions))
+	for _, p := range partitions {
+		partitionIDs[p.ID] = true
+	}
+
+	// file 필드까지 건너뜀 (다른 필드는 무시)
+	mr, err := c.Request.MultipartReader()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Expected multipart/form-data upload: %v", err)})
+		return
+	}
+	var file io.Reader
+	var filename string
+	for {
+		part, err := mr.NextPart()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Failed to read upload: %v", err)})
+			return
+		}
+		if part.FormName() == "file" {
+			file, filename = part, part.FileName()
+			break
+		}
+	}
+	if file == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file field is required"})
+		return
+	}
+
+	format := query.Get("format")
+	if format == "" {
+		format = formatFromFilename(filename)
+	}
+
+	counter := &countingReader{r: file}
+	source, err := newRecordSource(format, counter, query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	summary := &UploadSummary{
+		Topic:        topic,
+		Format:       format,
+		OffsetRanges: []OffsetRange{},
+		ranges:       make(map[int]*OffsetRange),
+	}
+	start := time.Now()
+
+	// SSE 진행 상황 (HTTP/1.1에서 본문을 읽는 중에 응답을 쓰려면 전이중 모드 필요)
+	stream := strings.Contains(c.GetHeader("Accept"), "text/event-stream")
+	if stream {
+		http.NewResponseController(c.Writer).EnableFullDuplex()
+		c.Writer.Header().Set("Content-Type", "text/event-stream")
+		c.Writer.Header().Set("Cache-Control", "no-cache")
+		c.Writer.Header().Set("Connection", "keep-alive")
+	}
+	emit := func(event string, data interface{}) {
+		if stream {
+			c.SSEvent(event, data)
+			c.Writer.Flush()
+		}
+	}
+
+	var readErr error
+	chunk := make([]kafka.Message, 0, chunkSize)
+	recordNumbers := make([]int, 0, chunkSize)
+	for done := false; !done; {
+		chunk, recordNumbers = chunk[:0], recordNumbers[:0]
+		for k := range fixed {
+			delete(fixed, k)
+		}
+
+		// 청크 채우기
+		for len(chunk) < chunkSize {
+			m, err := source.next()
+			if err == io.EOF {
+				done = true
+				break
+			}
+			var recErr *recordError
+			if err != nil && !errors.As(err, &recErr) {
+				readErr = err
+				done = true
+				break
+			}
+			summary.Records++
+			if err != nil {
+				summary.addError(summary.Records, recErr.Error())
+				continue
+			}
+
+			msg, err := m.message(opts.messageTime())
+			if err == nil && m.Partition != nil && !partitionIDs[*m.Partition] {
+				err = fmt.Errorf("partition %d does not exist in topic %s", *m.Partition, topic)
+			}
+			if err != nil {
+				summary.addError(summary.Records, err.Error())
+				continue
+			}
+
+			if m.Partition != nil {
+				fixed[len(chunk)] = *m.Partition
+			}
+			chunk = append(chunk, msg)
+			recordNumbers = append(recordNumbers, summary.Records)
+		}
+
+		if len(chunk) > 0 {
+			ctx, cancel := context.WithTimeout(c.Request.Context(), uploadChunkTimeout)
+			results := writeMessages(ctx, writer, chunk)
+			cancel()
+			summary.addResults(results, recordNumbers)
+		}
+
+		summary.BytesRead = counter.n
+		summary.DurationMs = time.Since(start).Milliseconds()
+		emit("progress", summary)
+
+		if c.Request.Context().Err() != nil {
+			return
+		}
+	}
+
+	summary.finish()
+	summary.BytesRead = counter.n
+	summary.DurationMs = time.Since(start).Milliseconds()
+
+	if readErr != nil {
+		errMsg := fmt.Sprintf("Failed to read upload: %v", readErr)
+		// 읽기 오류 전에 보낸 청크는 이미 기록됐으므로 오류 메시지(감사 기록)에도 범위를 남김
+		if summary.Sent > 0 {
+			errMsg = fmt.Sprintf("Failed to read upload after %d messages were already produced to %s: %v",
+				summary.Sent, summary.describeRanges(), readErr)
+		}
+		if stream {
+			emit("error", gin.H{"error": errMsg, "summary": summary})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg, "summary": summary})
+		return
+	}
+
+	if stream {
+		emit("summary", summary)
+		return
+	}
+
+	switch {
+	case summary.Failed == 0:
+		c.JSON(http.StatusOK, summary)
+	case summary.Sent > 0:
+		c.JSON(http.StatusMultiStatus, summary)
+	default:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "No messages were produced", "summary": summary})
+	}
+}
+
+// addError 레코드 오류 기록 (요약에는 최대 uploadMaxErrors개)
+func (s *UploadSummary) addError(record int, message string) {
+	s.Failed++
+	if len(s.Errors) >= uploadMaxErrors {
+		s.ErrorsTruncated = true
+		return
+	}
+	s.Errors = append(s.Errors, UploadRecordError{Record: record, Error: message})
+}
+
+// addResults 청크 전송 결과 반영 (오프셋 범위는 파티션별 최소/최대)
+func (s *UploadSummary) addResults(results []ProduceResult, recordNumbers []int) {
+	for i, r := range results {
+		if r.Error != "" {
+			s.addError(recordNumbers[i], r.Error)
+			continue
+		}
+		s.Sent++
+		if r.Partition == nil || r.Offset == nil {
+			continue // acks=none
+		}
+
+		rng, ok := s.ranges[*r.Partition]
+		if !ok {
+			rng = &OffsetRange{Partition: *r.Partition, FirstOffset: *r.Offset, LastOffset: *r.Offset}
+			s.ranges[*r.Partition] = rng
+		}
+		if *r.Offset < rng.FirstOffset {
+			rng.FirstOffset = *r.Offset
+		}
+		if *r.Offset > rng.LastOffset {
+			rng.LastOffset = *r.Offset
+		}
+		rng.Count++
+	}
+	s.finish()
+}
+
+// finish 오프셋 범위를 파티션 순으로 정리
+func (s *UploadSummary) finish() {
+	s.OffsetRanges = s.OffsetRanges[:0]
+	for _, rng := range s.ranges {
+		s.OffsetRanges = append(s.OffsetRanges, *rng)
+	}
+	sort.Slice(s.OffsetRanges, func(i, j int) bool {
+		return s.OffsetRanges[i].Partition < s.OffsetRanges[j].Partition
+	})
+}
+
+// describeRanges 파티션별 전송 오프셋 범위 요약 (예: "orders[0] 0-99, orders[1] 0-87")
+func (s *UploadSummary) describeRanges() string {
+	if len(s.OffsetRanges) == 0 {
+		return s.Topic
+	}
+	parts := make([]string, len(s.OffsetRanges))
+	for i, r := range s.OffsetRanges {
+		parts[i] = fmt.Sprintf("%s[%d] %d-%d", s.Topic, r.Partition, r.FirstOffset, r.LastOffset)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// recordSource 업로드 파일에서 메시지를 하나씩 읽는 파서
+//
+// next는 끝이면 io.EOF, 해당 레코드만 건너뛸 수 있는 오류면 *recordError를 반환한다.
+type recordSource interface {
+	next() (BatchMessage, error)
+}
+
+// recordError 레코드 하나의 파싱 오류 (전송은 계속함)
+type recordError struct {
+	err error
+}
+
+func (e *recordError) Error() string {
+	return e.err.Error()
+}
+
+// formatFromFilename 파일 확장자로 형식 추정
+func formatFromFilename(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".ndjson", ".jsonl":
+		return "ndjson"
+	case ".csv":
+		return "csv"
+	case ".gz", ".kbak":
+		return "backup"
+	}
+	return "kcat"
+}
+
+// newRecordSource 형식별 파서 생성
+func newRecordSource(format string, r io.Reader, query url.Values) (recordSource, error) {
+	switch format {
+	case "ndjson":
+		return &ndjsonSource{scanner: newDelimitedScanner(r, "\n")}, nil
+	case "csv":
+		return newCSVSource(r, query)
+	case "backup":
+		return newBackupSource(r, query)
+	case "kcat":
+		delimiter := query.Get("messageDelimiter")
+		if delimiter == "" {
+			delimiter = "\n"
+		}
+		return &kcatSource{
+			scanner:      newDelimitedScanner(r, unescapeDelimiter(delimiter)),
+			keyDelimiter: unescapeDelimiter(query.Get("keyDelimiter")),
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown format %q (expected ndjson, csv, kcat or backup)", format)
+}
+
+// ndjsonSource 줄마다 배치 메시지 JSON 하나 ({"key", "value", "nullKey", "nullValue", "partition", "timestamp", "headers"})
+type ndjsonSource struct {
+	scanner *bufio.Scanner
+}
+
+func (s *ndjsonSource) next() (BatchMessage, error) {
+	for s.scanner.Scan() {
+		line := bytes.TrimSpace(s.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var m BatchMessage
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&m); err != nil {
+			return BatchMessage{}, &recordError{err: fmt.Errorf("invalid JSON: %v", err)}
+		}
+		return m, nil
+	}
+	return BatchMessage{}, scanErr(s.scanner)
+}
+
+// kcatSource kcat -K 형식 ("키<구분자>값", 구분자가 없으면 키 없이 전체가 값)
+type kcatSource struct {
+	scanner      *bufio.Scanner
+	keyDelimiter string
+}
+
+func (s *kcatSource) next() (BatchMessage, error) {
+	for s.scanner.Scan() {
+		text := strings.TrimSuffix(s.scanner.Text(), "\r")
+		if text == "" {
+			continue
+		}
+		if s.keyDelimiter != "" {
+			if key, value, ok := strings.Cut(text, s.keyDelimiter); ok {
+				return BatchMessage{Key: key, Value: value}, nil
+			}
+		}
+		return BatchMessage{NullKey: true, Value: text}, nil
+	}
+	return BatchMessage{}, scanErr(s.scanner)
+}
+
+// csvSource 열 매핑에 따라 CSV 행을 메시지로 변환
+type csvSource struct {
+	reader        *csv.Reader
+	keyColumn     int
+	valueColumn   int
+	headerColumns []int
+	headerNames   []string
+}
+
+// newCSVSource CSV 파서 생성
+//
+// 열은 이름(header=true, 기본값) 또는 0부터 시작하는 번호로 지정한다.
+// keyColumn(기본 key), valueColumn(기본 value), headerColumns(쉼표 구분, 열 이름이 헤더 키).
+func newCSVSource(r io.Reader, query url.Values) (*csvSource, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	if d := unescapeDelimiter(query.Get("delimiter")); d != "" {
+		runes := []rune(d)
+		if len(runes) != 1 {
+			return nil, fmt.Errorf("delimiter must be a single character")
+		}
+		reader.Comma = runes[0]
+	}
+
+	hasHeader := query.Get("header") != "false"
+	var names []string
+	if hasHeader {
+		row, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV header: %v", err)
+		}
+		names = append(names, row...)
+	}
+
+	column := func(spec, fallback string) (int, error) {
+		if spec == "" {
+			spec = fallback
+		}
+		if n, err := strconv.Atoi(spec); err == nil && n >= 0 {
+			return n, nil
+		}
+		for i, name := range names {
+			if strings.TrimSpace(name) == spec {
+				return i, nil
+			}
+		}
+		return -1, fmt.Errorf("CSV column %q not found", spec)
+	}
+
+	keyFallback, valueFallback := "key", "value"
+	if !hasHeader {
+		keyFallback, valueFallback = "0", "1"
+	}
+
+	s := &csvSource{reader: reader}
+	var err error
+	if query.Get("keyColumn") == "-" {
+		s.keyColumn = -1 // 키 없음 (null)
+	} else if s.keyColumn, err = column(query.Get("keyColumn"), keyFallback); err != nil {
+		return nil, err
+	}
+	if s.valueColumn, err = column(query.Get("valueColumn"), valueFallback); err != nil {
+		return nil, err
+	}
+	if v := query.Get("headerColumns"); v != "" {
+		for _, spec := range strings.Split(v, ",") {
+			i, err := column(strings.TrimSpace(spec), "")
+			if err != nil {
+				return nil, err
+			}
+			name := strings.TrimSpace(spec)
+			if i < len(names) {
+				name = strings.TrimSpace(names[i])
+			}
+			s.headerColumns = append(s.headerColumns, i)
+			s.headerNames = append(s.headerNames, name)
+		}
+	}
+	return s, nil
+}
+
+func (s *csvSource) next() (BatchMessage, error) {
+	row, err := s.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return BatchMessage{}, &recordError{err: err}
+		}
+		return BatchMessage{}, err
+	}
+
+	field := func(i int) (string, error) {
+		if i >= len(row) {
+			return "", &recordError{err: fmt.Errorf("row has %d columns, column %d is missing", len(row), i)}
+		}
+		return row[i], nil
+	}
+
+	var m BatchMessage
+	if s.keyColumn < 0 {
+		m.NullKey = true
+	} else if m.Key, err = field(s.keyColumn); err != nil {
+		return BatchMessage{}, err
+	}
+	if m.Value, err = field(s.valueColumn); err != nil {
+		return BatchMessage{}, err
+	}
+	for i, col := range s.headerColumns {
+		value, err := field(col)
+		if err != nil {
+			return BatchMessage{}, err
+		}
+		m.Headers = append(m.Headers, MessageHeader{Key: s.headerNames[i], Value: value})
+	}
+	return m, nil
+}
+
+// newDelimitedScanner 구분자 단위로 레코드를 나누는 Scanner
+func newDelimitedScanner(r io.Reader, delimiter string) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), uploadMaxRecordBytes)
+	if delimiter == "\n" {
+		return scanner
+	}
+
+	sep := []byte(delimiter)
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		if i := bytes.Index(data, sep); i >= 0 {
+			return i + len(sep), data[:i], nil
+		}
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	})
+	return scanner
+}
+
+// scanErr Scanner 종료 사유 (정상 종료면 io.EOF)
+func scanErr(scanner *bufio.Scanner) error {
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return io.EOF
+}
+
+// unescapeDelimiter 쿼리로 받은 구분자의 \n, \t 이스케이프 해석
+func unescapeDelimiter(s string) string {
+	return strings.NewReplacer(`\n`, "\n", `\t`, "\t", `\r`, "\r").Replace(s)
+}
+
+// countingReader 읽은 바이트 수를 세는 Reader
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -2,34 +2,77 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/protocol/produce"
 )
 
 var kafkaWriter *kafka.Writer
 
+// batchOrdering 배치 전송 순서 보장 범위 (응답에 포함)
+const batchOrdering = "Messages are appended in request order within each partition. " +
+	"There is no ordering across partitions. A failed message does not stop later messages " +
+	"to the same partition, so check per-message results for gaps."
+
 // ProduceRequest 단일 메시지 전송 요청
 type ProduceRequest struct {
-	Topic     string `json:"topic" binding:"required"`
-	Key       string `json:"key"`
-	Value     string `json:"value" binding:"required"`
-	Partition *int   `json:"partition"`
+	Topic string `json:"topic" binding:"required"`
+	Key   string `json:"key"`
+	// NullKey true면 키를 null로 전송 (빈 문자열 키와 구분)
+	NullKey bool `json:"nullKey"`
+	// Value nullValue가 아니면 필수 (빈 문자열 허용)
+	Value *string `json:"value"`
+	// NullValue true면 값을 null로 전송 (compacted 토픽의 tombstone)
+	NullValue bool `json:"nullValue"`
+	Partition *int `json:"partition"`
+	ProducerOptions
 }
 
-// BatchMessage 배치 메시지
+// BatchMessage 배치 메시지 (파티션, 타임스탬프는 지정 시 요청 단위 설정보다 우선)
 type BatchMessage struct {
+	Key     string `json:"key"`
+	NullKey bool   `json:"nullKey"`
+	Value   string `json:"value"`
+	// NullValue true면 값을 null로 전송 (compacted 토픽의 tombstone)
+	NullValue bool            `json:"nullValue"`
+	Partition *int            `json:"partition"`
+	Timestamp *time.Time      `json:"timestamp"`
+	Headers   []MessageHeader `json:"headers"`
+}
+
+// MessageHeader 메시지 헤더 (같은 키를 여러 번 지정할 수 있음)
+type MessageHeader struct {
 	Key   string `json:"key"`
 	Value string `json:"value"`
+	// NullValue true면 헤더 값을 null로 전송 (빈 문자열 값과 구분)
+	NullValue bool `json:"nullValue,omitempty"`
 }
 
 // ProduceBatchRequest 배치 메시지 전송 요청
 type ProduceBatchRequest struct {
 	Topic    string         `json:"topic" binding:"required"`
 	Messages []BatchMessage `json:"messages" binding:"required"`
+	ProducerOptions
+}
+
+// ProduceResult 메시지별 전송 결과 (실패 시 파티션, 오프셋, 타임스탬프 없음, acks=none이면 오프셋과 타임스탬프 없음)
+type ProduceResult struct {
+	Index     int        `json:"index"`
+	Topic     string     `json:"topic,omitempty"` // 트랜잭션 전송에서만 포함
+	Key       string     `json:"key,omitempty"`
+	Partition *int       `json:"partition,omitempty"`
+	Offset    *int64     `json:"offset,omitempty"`
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+	// TimestampType CreateTime(전송 시 지정한 시각) 또는 LogAppendTime(브로커 기록 시각)
+	TimestampType string `json:"timestamp_type,omitempty"`
+	Error         string `json:"error,omitempty"`
 }
 
 // ProduceMessage 단일 메시지 전송 핸들러
@@ -40,49 +83,76 @@ func ProduceMessage(c *gin.Context) {
 		return
 	}
 
+	switch {
+	case req.NullKey && req.Key != "":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "key must be empty when nullKey is true"})
+		return
+	case req.NullValue && req.Value != nil:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "value must be omitted when nullValue is true"})
+		return
+	case !req.NullValue && req.Value == nil:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "value is required (set nullValue to send a tombstone)"})
+		return
+	}
+
+	// 파티션이 지정된 경우 Balancer 대신 해당 파티션으로 전송
+	var fixed map[int]int
+	if req.Partition != nil {
+		if err := checkPartitionExists(req.Topic, *req.Partition); err != nil {
+			respondPartitionError(c, err)
+			return
+		}
+		fixed = map[int]int{0: *req.Partition}
+	}
+
 	// Writer 생성 (토픽별로)
-	writer := &kafka.Writer{
-		Addr:         kafka.TCP(kafkaBrokers),
-		Topic:        req.Topic,
-		Balancer:     &kafka.Hash{}, // Key 기반 파티션 분배
-		RequiredAcks: kafka.RequireAll,
-		Async:        false,
+	writer, err := req.ProducerOptions.newWriter(req.Topic, fixed)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 	defer writer.Close()
 
 	// 메시지 생성
 	msg := kafka.Message{
-		Key:   []byte(req.Key),
-		Value: []byte(req.Value),
-		Time:  time.Now(),
+		Key:  nullableBytes(req.Key, req.NullKey),
+		Time: req.messageTime(),
 	}
-
-	// 파티션이 지정된 경우
-	if req.Partition != nil {
-		msg.Partition = *req.Partition
+	if !req.NullValue {
+		msg.Value = nullableBytes(*req.Value, false)
 	}
 
 	// 메시지 전송
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	err := writer.WriteMessages(ctx, msg)
-	if err != nil {
+	var results []ProduceResult
+	if req.Idempotent {
+		results = writeIdempotent(ctx, writer, []kafka.Message{msg})
+	} else {
+		results = writeMessages(ctx, writer, []kafka.Message{msg})
+	}
+	result := results[0]
+	if result.Error != "" {
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": fmt.Sprintf("Failed to produce message: %v", err),
+			"error": fmt.Sprintf("Failed to produce message: %s", result.Error),
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"status":  "success",
-		"topic":   req.Topic,
-		"key":     req.Key,
-		"message": "Message sent successfully",
+		"status":         "success",
+		"topic":          req.Topic,
+		"key":            req.Key,
+		"partition":      result.Partition,
+		"offset":         result.Offset,
+		"timestamp":      result.Timestamp,
+		"timestamp_type": result.TimestampType,
+		"message":        "Message sent successfully",
 	})
 }
 
-// ProduceBatchMessages 배치 메시지 전송 핸들러
+// ProduceBatchMessages 배치 메시지 전송 핸들러 (일부만 실패하면 207과 메시지별 결과)
 func ProduceBatchMessages(c *gin.Context) {
 	var req ProduceBatchRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -90,42 +160,277 @@ func ProduceBatchMessages(c *gin.Context) {
 		return
 	}
 
+	// 메시지별로 지정된 파티션 확인
+	fixed := make(map[int]int)
+	for i, m := range req.Messages {
+		if m.Partition != nil {
+			fixed[i] = *m.Partition
+		}
+	}
+	if err := checkPartitionsExist(req.Topic, fixed); err != nil {
+		respondPartitionError(c, err)
+		return
+	}
+
 	// Writer 생성
-	writer := &kafka.Writer{
-		Addr:         kafka.TCP(kafkaBrokers),
-		Topic:        req.Topic,
-		Balancer:     &kafka.Hash{},
-		RequiredAcks: kafka.RequireAll,
-		Async:        false,
+	writer, err := req.ProducerOptions.newWriter(req.Topic, fixed)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 	defer writer.Close()
 
 	// 배치 메시지 생성
 	messages := make([]kafka.Message, len(req.Messages))
 	for i, m := range req.Messages {
-		messages[i] = kafka.Message{
-			Key:   []byte(m.Key),
-			Value: []byte(m.Value),
-			Time:  time.Now(),
+		msg, err := m.message(req.messageTime())
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("messages[%d]: %v", i, err)})
+			return
 		}
+		messages[i] = msg
 	}
 
 	// 배치 전송
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	err := writer.WriteMessages(ctx, messages...)
-	if err != nil {
+	var results []ProduceResult
+	if req.Idempotent {
+		results = writeIdempotent(ctx, writer, messages)
+	} else {
+		results = writeMessages(ctx, writer, messages)
+	}
+
+	failed := 0
+	var firstErr string
+	for _, r := range results {
+		if r.Error != "" {
+			if failed == 0 {
+				firstErr = r.Error
+			}
+			failed++
+		}
+	}
+
+	status, code, message := "success", http.StatusOK, "Batch messages sent successfully"
+	switch {
+	case failed > 0 && failed == len(results):
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": fmt.Sprintf("Failed to produce batch messages: %v", err),
+			"error":    fmt.Sprintf("Failed to produce batch messages: %s", firstErr),
+			"results":  results,
+			"ordering": batchOrdering,
 		})
 		return
+	case failed > 0:
+		status, code = "partial", http.StatusMultiStatus
+		message = fmt.Sprintf("%d of %d batch messages failed", failed, len(results))
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"status":        "success",
+	c.JSON(code, gin.H{
+		"status":        status,
 		"topic":         req.Topic,
 		"message_count": len(req.Messages),
-		"message":       "Batch messages sent successfully",
+		"succeeded":     len(results) - failed,
+		"failed":        failed,
+		"results":       results,
+		"ordering":      batchOrdering,
+		"message":       message,
 	})
 }
+
+// writeMessages 메시지를 동기 전송하고 요청 순서대로 메시지별 파티션, 오프셋, 타임스탬프 반환
+func writeMessages(ctx context.Context, writer *kafka.Writer, messages []kafka.Message) []ProduceResult {
+	results := make([]ProduceResult, len(messages))
+	for i := range messages {
+		results[i] = ProduceResult{Index: i, Key: string(messages[i].Key)}
+		messages[i].WriterData = i
+	}
+
+	transport := &appendTimeTransport{times: make(map[partitionOffset]time.Time)}
+	writer.Transport = transport
+
+	// Completion은 파티션 배치 단위로 호출되며 각 메시지에 할당된 파티션과 오프셋이 채워져 있음
+	var mu sync.Mutex
+	writer.Completion = func(batch []kafka.Message, err error) {
+		if err != nil || len(batch) == 0 {
+			return
+		}
+		appendTime, logAppend := transport.appendTime(batch[0].Partition, batch[0].Offset)
+
+		mu.Lock()
+		defer mu.Unlock()
+		for _, m := range batch {
+			i, ok := m.WriterData.(int)
+			if !ok {
+				continue
+			}
+			partition, offset, timestamp := m.Partition, m.Offset, m.Time
+			results[i].Partition = &partition
+			if writer.RequiredAcks == kafka.RequireNone {
+				// acks=none이면 브로커 응답이 없어 오프셋을 알 수 없음
+				continue
+			}
+			results[i].Offset = &offset
+			results[i].TimestampType = "CreateTime"
+			if logAppend {
+				timestamp = appendTime
+				results[i].TimestampType = "LogAppendTime"
+			}
+			results[i].Timestamp = &timestamp
+		}
+	}
+
+	err := writer.WriteMessages(ctx, messages...)
+
+	var writeErrs kafka.WriteErrors
+	switch {
+	case err == nil:
+	case errors.As(err, &writeErrs) && len(writeErrs) == len(messages):
+		for i, e := range writeErrs {
+			if e != nil {
+				results[i].Error = e.Error()
+			}
+		}
+	default:
+		for i := range results {
+			results[i].Error = err.Error()
+		}
+	}
+
+	for i := range results {
+		if results[i].Error != "" {
+			results[i].Partition, results[i].Offset, results[i].Timestamp, results[i].TimestampType = nil, nil, nil, ""
+		}
+	}
+	return results
+}
+
+// message 배치 메시지를 Kafka 메시지로 변환 (타임스탬프를 지정하지 않았으면 defaultTime)
+func (m BatchMessage) message(defaultTime time.Time) (kafka.Message, error) {
+	if m.NullKey && m.Key != "" {
+		return kafka.Message{}, errors.New("key must be empty when nullKey is true")
+	}
+	if m.NullValue && m.Value != "" {
+		return kafka.Message{}, errors.New("value must be empty when nullValue is true")
+	}
+
+	msg := kafka.Message{
+		Key:   nullableBytes(m.Key, m.NullKey),
+		Value: nullableBytes(m.Value, m.NullValue),
+		Time:  defaultTime,
+	}
+	if m.Timestamp != nil {
+		msg.Time = *m.Timestamp
+	}
+	for _, h := range m.Headers {
+		if h.Key == "" {
+			return kafka.Message{}, errors.New("header key is required")
+		}
+		if h.NullValue && h.Value != "" {
+			return kafka.Message{}, fmt.Errorf("header %s: value must be empty when nullValue is true", h.Key)
+		}
+		msg.Headers = append(msg.Headers, kafka.Header{Key: h.Key, Value: nullableBytes(h.Value, h.NullValue)})
+	}
+	return msg, nil
+}
+
+// nullableBytes null이면 nil, 아니면 빈 문자열도 길이 0인 값으로 전송되는 바이트
+func nullableBytes(s string, null bool) []byte {
+	if null {
+		return nil
+	}
+	return append([]byte{}, s...)
+}
+
+// checkPartitionExists 지정한 파티션이 토픽에 있는지 확인
+func checkPartitionExists(topic string, partition int) error {
+	return checkPartitionsExist(topic, map[int]int{0: partition})
+}
+
+// checkPartitionsExist 메시지별로 지정한 파티션이 모두 토픽에 있는지 확인
+func checkPartitionsExist(topic string, fixed map[int]int) error {
+	if len(fixed) == 0 {
+		return nil
+	}
+
+	partitions, err := readPartitions(topic)
+	if err != nil {
+		return err
+	}
+
+	ids := make(map[int]bool, len(partitions))
+	for _, p := range partitions {
+		ids[p.ID] = true
+	}
+	for _, partition := range fixed {
+		if !ids[partition] {
+			return &partitionNotFoundError{topic: topic, partition: partition, count: len(partitions)}
+		}
+	}
+	return nil
+}
+
+// partitionNotFoundError 토픽에 없는 파티션 지정
+type partitionNotFoundError struct {
+	topic     string
+	partition int
+	count     int
+}
+
+func (e *partitionNotFoundError) Error() string {
+	return fmt.Sprintf("partition %d does not exist in topic %s (%d partitions)", e.partition, e.topic, e.count)
+}
+
+// respondPartitionError 파티션 확인 실패 응답 (없는 파티션은 400)
+func respondPartitionError(c *gin.Context, err error) {
+	var notFound *partitionNotFoundError
+	if errors.As(err, &notFound) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": fmt.Sprintf("Failed to read topic partitions: %v", err),
+	})
+}
+
+// partitionOffset 파티션과 배치 첫 오프셋
+type partitionOffset struct {
+	partition int
+	offset    int64
+}
+
+// appendTimeTransport Produce 응답의 LogAppendTime을 기록하는 Transport
+//
+// kafka-go Writer는 메시지에 시각이 지정되어 있으면 브로커가 돌려준 LogAppendTime을
+// 버리기 때문에, message.timestamp.type=LogAppendTime 토픽의 브로커 기록 시각은
+// 응답에서 직접 가져온다.
+type appendTimeTransport struct {
+	mu    sync.Mutex
+	times map[partitionOffset]time.Time
+}
+
+func (t *appendTimeTransport) RoundTrip(ctx context.Context, addr net.Addr, req kafka.Request) (kafka.Response, error) {
+	resp, err := kafka.DefaultTransport.RoundTrip(ctx, addr, req)
+	if r, ok := resp.(*produce.Response); ok && err == nil {
+		t.mu.Lock()
+		for _, topic := range r.Topics {
+			for _, p := range topic.Partitions {
+				if p.ErrorCode == 0 && p.LogAppendTime > 0 {
+					key := partitionOffset{partition: int(p.Partition), offset: p.BaseOffset}
+					t.times[key] = time.UnixMilli(p.LogAppendTime).UTC()
+				}
+			}
+		}
+		t.mu.Unlock()
+	}
+	return resp, err
+}
+
+// appendTime 배치의 LogAppendTime (CreateTime 토픽이면 false)
+func (t *appendTimeTransport) appendTime(partition int, baseOffset int64) (time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ts, ok := t.times[partitionOffset{partition: partition, offset: baseOffset}]
+	return ts, ok
+}
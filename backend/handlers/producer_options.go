@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// ProducerOptions 요청별 Producer 설정 (생략 시 hash, acks=all, 압축 없음, 현재 시각, 멱등 아님)
+type ProducerOptions struct {
+	// Balancer hash, murmur2(Java 호환), round-robin, least-bytes, sticky
+	Balancer string `json:"balancer"`
+	// Acks none, leader, all
+	Acks string `json:"acks"`
+	// Compression none, gzip, snappy, lz4, zstd
+	Compression string `json:"compression"`
+	// Timestamp 메시지 타임스탬프 지정 (RFC3339)
+	Timestamp *time.Time `json:"timestamp"`
+	// Idempotent producer id와 시퀀스 번호를 붙여 재시도해도 중복 없이 전송 (acks=all 필요)
+	Idempotent bool `json:"idempotent"`
+}
+
+// newWriter 설정에 맞는 토픽 Writer 생성
+//
+// fixed에 메시지 인덱스(WriterData)별 파티션이 있으면 Balancer 대신 그 파티션으로 보낸다.
+// fixed는 Writer를 재사용하며 전송할 때마다 내용을 바꿀 수 있다.
+func (o ProducerOptions) newWriter(topic string, fixed map[int]int) (*kafka.Writer, error) {
+	balancer, err := o.balancer()
+	if err != nil {
+		return nil, err
+	}
+	acks, err := o.requiredAcks()
+	if err != nil {
+		return nil, err
+	}
+	compression, err := o.compression()
+	if err != nil {
+		return nil, err
+	}
+	if o.Idempotent && acks != kafka.RequireAll {
+		return nil, fmt.Errorf("idempotent produce requires acks=all")
+	}
+
+	if fixed != nil {
+		balancer = &fixedPartitionBalancer{partitions: fixed, next: balancer}
+	}
+
+	return &kafka.Writer{
+		Addr:         kafka.TCP(kafkaBrokers),
+		Topic:        topic,
+		Balancer:     balancer,
+		RequiredAcks: acks,
+		Compression:  compression,
+		Async:        false,
+	}, nil
+}
+
+// messageTime 메시지 타임스탬프 (지정하지 않으면 현재 시각)
+func (o ProducerOptions) messageTime() time.Time {
+	if o.Timestamp != nil {
+		return *o.Timestamp
+	}
+	return time.Now()
+}
+
+// balancer 파티션 분배 방식
+func (o ProducerOptions) balancer() (kafka.Balancer, error) {
+	switch o.Balancer {
+	case "", "hash":
+		return &kafka.Hash{}, nil // Key 기반 파티션 분배 (FNV-1a)
+	case "murmur2":
+		return kafka.Murmur2Balancer{}, nil // Java 기본 partitioner와 같은 Key 해시
+	case "round-robin":
+		return &kafka.RoundRobin{}, nil
+	case "least-bytes":
+		return &kafka.LeastBytes{}, nil
+	case "sticky":
+		return &stickyBalancer{}, nil
+	}
+	return nil, fmt.Errorf("unknown balancer %q (expected hash, murmur2, round-robin, least-bytes or sticky)", o.Balancer)
+}
+
+// requiredAcks 응답 대기 수준
+func (o ProducerOptions) requiredAcks() (kafka.RequiredAcks, error) {
+	switch o.Acks {
+	case "", "all":
+		return kafka.RequireAll, nil
+	case "leader":
+		return kafka.RequireOne, nil
+	case "none":
+		return kafka.RequireNone, nil
+	}
+	return 0, fmt.Errorf("unknown acks %q (expected none, leader or all)", o.Acks)
+}
+
+// compression 압축 코덱
+func (o ProducerOptions) compression() (kafka.Compression, error) {
+	switch o.Compression {
+	case "", "none":
+		return 0, nil
+	case "gzip":
+		return kafka.Gzip, nil
+	case "snappy":
+		return kafka.Snappy, nil
+	case "lz4":
+		return kafka.Lz4, nil
+	case "zstd":
+		return kafka.Zstd, nil
+	}
+	return 0, fmt.Errorf("unknown compression %q (expected none, gzip, snappy, lz4 or zstd)", o.Compression)
+}
+
+// stickyBalancer Java 기본 partitioner 동작 재현
+//
+// Key가 있으면 murmur2 해시, Key가 null이면 요청 동안 임의로 고른 한 파티션에 몰아서 보낸다.
+type stickyBalancer struct {
+	murmur2 kafka.Murmur2Balancer
+
+	mu        sync.Mutex
+	partition int
+	chosen    bool
+}
+
+func (b *stickyBalancer) Balance(msg kafka.Message, partitions ...int) int {
+	if msg.Key != nil {
+		return b.murmur2.Balance(msg, partitions...)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.chosen || !containsPartition(partitions, b.partition) {
+		b.partition = partitions[rand.Intn(len(partitions))]
+		b.chosen = true
+	}
+	return b.partition
+}
+
+// fixedPartitionBalancer 파티션이 지정된 메시지는 그대로, 나머지는 next로 분배
+type fixedPartitionBalancer struct {
+	partitions map[int]int
+	next       kafka.Balancer
+}
+
+func (b *fixedPartitionBalancer) Balance(msg kafka.Message, partitions ...int) int {
+	if i, ok := msg.WriterData.(int); ok {
+		if p, ok := b.partitions[i]; ok {
+			return p
+		}
+	}
+	return b.next.Balance(msg, partitions...)
+}
+
+// containsPartition 파티션 목록에 포함되는지 확인
+func containsPartition(partitions []int, partition int) bool {
+	for _, p := range partitions {
+		if p == partition {
+			return true
+		}
+	}
+	return false
+}
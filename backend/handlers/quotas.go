@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
+)
+
+// defaultQuotaEntity 기본값(default) 엔티티를 나타내는 이름
+const defaultQuotaEntity = "<default>"
+
+// 클라이언트 쿼터 엔티티 유형
+const (
+	quotaEntityUser     = "user"
+	quotaEntityClientID = "client-id"
+)
+
+// supportedQuotaKeys 관리 대상 쿼터 키
+var supportedQuotaKeys = map[string]bool{
+	"producer_byte_rate": true,
+	"consumer_byte_rate": true,
+	"request_percentage": true,
+}
+
+// ClientQuota 클라이언트 쿼터 항목
+type ClientQuota struct {
+	User     string             `json:"user,omitempty"`
+	ClientID string             `json:"client_id,omitempty"`
+	Values   map[string]float64 `json:"values"`
+}
+
+// AlterClientQuotaRequest 클라이언트 쿼터 변경 요청
+type AlterClientQuotaRequest struct {
+	// User 사용자 principal 이름, "<default>"는 사용자 기본값
+	User string `json:"user"`
+	// ClientID 클라이언트 ID, "<default>"는 클라이언트 ID 기본값
+	ClientID string `json:"clientId"`
+	// Set 설정할 쿼터 (producer_byte_rate, consumer_byte_rate, request_percentage)
+	Set map[string]float64 `json:"set"`
+	// Remove 제거할 쿼터 키
+	Remove       []string `json:"remove"`
+	ValidateOnly bool     `json:"validateOnly"`
+}
+
+// GetClientQuotas 클라이언트 쿼터 조회 (?user=, ?clientId= 필터, "<default>"로 기본값 조회)
+func GetClientQuotas(c *gin.Context) {
+	var components []kafka.DescribeClientQuotasRequestComponent
+	if user, ok := c.GetQuery("user"); ok {
+		components = append(components, quotaMatchComponent(quotaEntityUser, user))
+	}
+	if clientID, ok := c.GetQuery("clientId"); ok {
+		components = append(components, quotaMatchComponent(quotaEntityClientID, clientID))
+	}
+
+	quotas, err := describeClientQuotas(components)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to describe client quotas: %v", err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"quotas": quotas,
+		"count":  len(quotas),
+	})
+}
+
+// AlterClientQuota 사용자/클라이언트 ID 쿼터 설정 또는 제거
+func AlterClientQuota(c *gin.Context) {
+	var req AlterClientQuotaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.User == "" && req.ClientID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "user or clientId is required (use \"<default>\" for the default entity)",
+		})
+		return
+	}
+
+	if len(req.Set) == 0 && len(req.Remove) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "set or remove is required",
+		})
+		return
+	}
+
+	var ops []kafka.AlterClientQuotaOps
+	for key, value := range req.Set {
+		if !supportedQuotaKeys[key] {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("unsupported quota %q", key),
+			})
+			return
+		}
+		if value <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("quota %q must be positive", key),
+			})
+			return
+		}
+		ops = append(ops, kafka.AlterClientQuotaOps{Key: key, Value: value})
+	}
+	for _, key := range req.Remove {
+		if !supportedQuotaKeys[key] {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("unsupported quota %q", key),
+			})
+			return
+		}
+		if _, ok := req.Set[key]; ok {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("quota %q is both set and removed", key),
+			})
+			return
+		}
+		ops = append(ops, kafka.AlterClientQuotaOps{Key: key, Remove: true})
+	}
+
+	var entities []kafka.AlterClientQuotaEntity
+	if req.User != "" {
+		entities = append(entities, kafka.AlterClientQuotaEntity{
+			EntityType: quotaEntityUser,
+			EntityName: quotaEntityName(req.User),
+		})
+	}
+	if req.ClientID != "" {
+		entities = append(entities, kafka.AlterClientQuotaEntity{
+			EntityType: quotaEntityClientID,
+			EntityName: quotaEntityName(req.ClientID),
+		})
+	}
+
+	client := newKafkaClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := client.AlterClientQuotas(ctx, &kafka.AlterClientQuotasRequest{
+		Entries: []kafka.AlterClientQuotaEntry{
+			{Entities: entities, Ops: ops},
+		},
+		ValidateOnly: req.ValidateOnly,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to alter client quotas: %v", err),
+		})
+		return
+	}
+
+	for _, entry := range resp.Entries {
+		if entry.Error != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("Failed to alter client quotas: %v", entry.Error),
+			})
+			return
+		}
+	}
+
+	status := "success"
+	message := "Client quotas altered successfully"
+	if req.ValidateOnly {
+		status = "validated"
+		message = "Client quota changes are valid (nothing applied)"
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    status,
+		"user":      req.User,
+		"client_id": req.ClientID,
+		"set":       req.Set,
+		"remove":    req.Remove,
+		"message":   message,
+	})
+}
+
+// describeClientQuotas 조건과 일치하는 쿼터 조회 (조건이 없으면 전체)
+func describeClientQuotas(components []kafka.DescribeClientQuotasRequestComponent) ([]ClientQuota, error) {
+	client := newKafkaClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := client.DescribeClientQuotas(ctx, &kafka.DescribeClientQuotasRequest{
+		Components: components,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+
+	quotas := make([]ClientQuota, 0, len(resp.Entries))
+	for _, entry := range resp.Entries {
+		quota := ClientQuota{Values: make(map[string]float64)}
+		for _, entity := range entry.Entities {
+			name := entity.EntityName
+			if name == "" {
+				name = defaultQuotaEntity
+			}
+			switch entity.EntityType {
+			case quotaEntityUser:
+				quota.User = name
+			case quotaEntityClientID:
+				quota.ClientID = name
+			}
+		}
+		for _, v := range entry.Values {
+			quota.Values[v.Key] = v.Value
+		}
+		quotas = append(quotas, quota)
+	}
+
+	sort.Slice(quotas, func(i, j int) bool {
+		if quotas[i].User != quotas[j].User {
+			return quotas[i].User < quotas[j].User
+		}
+		return quotas[i].ClientID < quotas[j].ClientID
+	})
+
+	return quotas, nil
+}
+
+// quotaMatchComponent 조회 조건 생성 (MatchType 0: 정확히 일치, 1: 기본값)
+func quotaMatchComponent(entityType, name string) kafka.DescribeClientQuotasRequestComponent {
+	if name == defaultQuotaEntity {
+		return kafka.DescribeClientQuotasRequestComponent{EntityType: entityType, MatchType: 1}
+	}
+	if name == "" {
+		// 이름 없이 지정하면 해당 유형이 포함된 모든 쿼터
+		return kafka.DescribeClientQuotasRequestComponent{EntityType: entityType, MatchType: 2}
+	}
+	return kafka.DescribeClientQuotasRequestComponent{EntityType: entityType, MatchType: 0, Match: name}
+}
+
+// quotaEntityName 요청의 엔티티 이름을 프로토콜 값으로 변환 (기본값은 null)
+func quotaEntityName(name string) string {
+	if name == defaultQuotaEntity {
+		return ""
+	}
+	return name
+}
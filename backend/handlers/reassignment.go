@@ -0,0 +1,941 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/protocol/alterpartitionreassignments"
+)
+
+// ReassignmentPlanRequest 재배치 계획 생성 요청
+type ReassignmentPlanRequest struct {
+	Topics  []string `json:"topics" binding:"required,min=1"`
+	Brokers []int    `json:"brokers" binding:"required,min=1"`
+	// IgnoreRacks true면 BrokerInfo.Rack을 무시하고 부하만 고려
+	IgnoreRacks bool `json:"ignoreRacks"`
+}
+
+// PartitionReassignment 파티션 재배치 항목 (계획 응답과 실행 요청에 공통 사용)
+type PartitionReassignment struct {
+	Topic           string `json:"topic" binding:"required"`
+	Partition       int    `json:"partition"`
+	CurrentReplicas []int  `json:"current_replicas,omitempty"`
+	Replicas        []int  `json:"replicas" binding:"required,min=1"`
+}
+
+// ExecuteReassignmentRequest 재배치 실행 요청
+type ExecuteReassignmentRequest struct {
+	Partitions []PartitionReassignment `json:"partitions" binding:"required,min=1,dive"`
+	// Throttle 복제 스로틀 (bytes/sec, 0이면 미적용)
+	Throttle int64 `json:"throttle" binding:"min=0"`
+}
+
+// ReassignmentProgress 파티션별 재배치 진행 상황
+type ReassignmentProgress struct {
+	Topic            string `json:"topic"`
+	Partition        int    `json:"partition"`
+	Status           string `json:"status"` // in_progress, completed, not_started
+	Replicas         []int  `json:"replicas"`
+	TargetReplicas   []int  `json:"target_replicas,omitempty"`
+	AddingReplicas   []int  `json:"adding_replicas,omitempty"`
+	RemovingReplicas []int  `json:"removing_replicas,omitempty"`
+	ISR              []int  `json:"isr"`
+}
+
+// reassignmentState 마지막으로 실행한 재배치 정보 (스로틀 해제 및 진행률 계산용)
+type reassignmentState struct {
+	StartedAt        time.Time
+	Partitions       []PartitionReassignment
+	Throttle         int64
+	ThrottledBrokers []int
+	ThrottledTopics  []string
+	// ThrottleError 마지막 스로틀 해제 실패 사유 (성공하면 비움)
+	ThrottleError string
+}
+
+// throttled 해제되지 않은 스로틀 설정이 남아 있는지 여부
+func (s *reassignmentState) throttled() bool {
+	return len(s.ThrottledBrokers) > 0 || len(s.ThrottledTopics) > 0
+}
+
+var (
+	reassignmentMu     sync.Mutex
+	activeReassignment *reassignmentState
+)
+
+// reassignmentPollInterval 스로틀 감시자가 재배치 완료 여부를 확인하는 주기
+const reassignmentPollInterval = 10 * time.Second
+
+// 스로틀 관련 설정 이름
+const (
+	leaderThrottleRate        = "leader.replication.throttled.rate"
+	followerThrottleRate      = "follower.replication.throttled.rate"
+	leaderThrottledReplicas   = "leader.replication.throttled.replicas"
+	followerThrottledReplicas = "follower.replication.throttled.replicas"
+)
+
+// PlanReassignment 선택한 토픽을 선택한 브로커에 균등 분배하는 재배치 계획 생성
+func PlanReassignment(c *gin.Context) {
+	var req ReassignmentPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	conn, err := kafka.Dial("tcp", kafkaBrokers)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to connect to Kafka: %v", err),
+		})
+		return
+	}
+	defer conn.Close()
+
+	brokers, err := conn.Brokers()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to get brokers: %v", err),
+		})
+		return
+	}
+
+	racks := make(map[int]string)
+	for _, b := range brokers {
+		racks[b.ID] = b.Rack
+	}
+
+	targets := make([]int, 0, len(req.Brokers))
+	for _, id := range req.Brokers {
+		if _, ok := racks[id]; !ok {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("broker %d is not part of the cluster", id),
+			})
+			return
+		}
+		if !containsInt(targets, id) {
+			targets = append(targets, id)
+		}
+	}
+
+	partitions, err := conn.ReadPartitions(req.Topics...)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to read partitions: %v", err),
+		})
+		return
+	}
+
+	found := make(map[string]bool)
+	for _, p := range partitions {
+		found[p.Topic] = true
+	}
+	for _, topic := range req.Topics {
+		if !found[topic] {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": fmt.Sprintf("Topic not found: %s", topic),
+			})
+			return
+		}
+	}
+
+	rackAware := !req.IgnoreRacks && hasRacks(racks, targets)
+
+	plan, load, err := planReassignment(partitions, targets, racks, rackAware)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"partitions":       plan,
+		"moves":            len(plan),
+		"total_partitions": len(partitions),
+		"rack_aware":       rackAware,
+		"broker_load":      load,
+	})
+}
+
+// ExecuteReassignment 재배치 계획 실행 (선택적으로 복제 스로틀 적용)
+func ExecuteReassignment(c *gin.Context) {
+	var req ExecuteReassignmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	conn, err := kafka.Dial("tcp", kafkaBrokers)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to connect to Kafka: %v", err),
+		})
+		return
+	}
+	defer conn.Close()
+
+	// 현재 레플리카 정보 채우기 (스로틀 대상 계산에 필요)
+	current := make(map[string][]int)
+	partitions, err := conn.ReadPartitions(reassignmentTopics(req.Partitions)...)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to read partitions: %v", err),
+		})
+		return
+	}
+	for _, p := range partitions {
+		current[partitionKey(p.Topic, p.ID)] = getReplicaIDs(p.Replicas)
+	}
+	for i, p := range req.Partitions {
+		replicas, ok := current[partitionKey(p.Topic, p.Partition)]
+		if !ok {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": fmt.Sprintf("Partition not found: %s-%d", p.Topic, p.Partition),
+			})
+			return
+		}
+		req.Partitions[i].CurrentReplicas = replicas
+	}
+
+	reassignmentMu.Lock()
+	defer reassignmentMu.Unlock()
+
+	// 다른 재배치가 진행 중이면 스로틀과 진행률 추적이 뒤섞이므로 거부
+	ongoing, err := listOngoingReassignments()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to list reassignments: %v", err),
+		})
+		return
+	}
+	if len(ongoing) > 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":       fmt.Sprintf("%d partition reassignments are already in progress, wait for them to finish or cancel them first", len(ongoing)),
+			"in_progress": len(ongoing),
+		})
+		return
+	}
+
+	// 이전 실행에서 해제되지 않은 스로틀 정리
+	if activeReassignment != nil && activeReassignment.throttled() {
+		if err := removeReassignmentThrottle(activeReassignment); err != nil {
+			activeReassignment.ThrottleError = err.Error()
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": fmt.Sprintf("Failed to remove previous replication throttle: %v", err),
+			})
+			return
+		}
+	}
+
+	state := &reassignmentState{
+		StartedAt:  time.Now(),
+		Partitions: req.Partitions,
+		Throttle:   req.Throttle,
+	}
+
+	if req.Throttle > 0 {
+		if err := applyReassignmentThrottle(state); err != nil {
+			if rerr := removeReassignmentThrottle(state); rerr != nil {
+				err = fmt.Errorf("%w (rollback failed: %v)", err, rerr)
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": fmt.Sprintf("Failed to apply replication throttle: %v", err),
+			})
+			return
+		}
+	}
+
+	assignments := make([]kafka.AlterPartitionReassignmentsRequestAssignment, 0, len(req.Partitions))
+	for _, p := range req.Partitions {
+		assignments = append(assignments, kafka.AlterPartitionReassignmentsRequestAssignment{
+			Topic:       p.Topic,
+			PartitionID: p.Partition,
+			BrokerIDs:   p.Replicas,
+		})
+	}
+
+	client := newKafkaClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	resp, err := client.AlterPartitionReassignments(ctx, &kafka.AlterPartitionReassignmentsRequest{
+		Assignments: assignments,
+		Timeout:     30 * time.Second,
+	})
+	if err == nil && resp.Error != nil {
+		err = resp.Error
+	}
+	if err != nil {
+		if rerr := removeReassignmentThrottle(state); rerr != nil {
+			err = fmt.Errorf("%w (throttle removal failed: %v)", err, rerr)
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to execute reassignment: %v", err),
+		})
+		return
+	}
+
+	results := make([]gin.H, 0, len(resp.PartitionResults))
+	failed := 0
+	var firstErr error
+	for _, r := range resp.PartitionResults {
+		result := gin.H{
+			"topic":     r.Topic,
+			"partition": r.PartitionID,
+			"status":    "started",
+		}
+		if r.Error != nil {
+			result["status"] = "failed"
+			result["error"] = r.Error.Error()
+			if failed == 0 {
+				firstErr = r.Error
+			}
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	// 하나도 시작되지 않았으면 추적할 재배치가 없으므로 스로틀을 바로 해제
+	if len(results) > 0 && failed == len(results) {
+		err := firstErr
+		if rerr := removeReassignmentThrottle(state); rerr != nil {
+			err = fmt.Errorf("%w (throttle removal failed: %v)", err, rerr)
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   fmt.Sprintf("Failed to execute reassignment: %v", err),
+			"results": results,
+			"failed":  failed,
+		})
+		return
+	}
+
+	activeReassignment = state
+	if state.throttled() {
+		go watchReassignmentThrottle(state)
+	}
+
+	status, code := "success", http.StatusOK
+	if failed > 0 {
+		status, code = "partial", http.StatusMultiStatus
+	}
+
+	c.JSON(code, gin.H{
+		"status":     status,
+		"started_at": state.StartedAt,
+		"throttle":   req.Throttle,
+		"results":    results,
+		"failed":     failed,
+		"message":    "Reassignment started, poll GET /api/reassignments for progress",
+	})
+}
+
+// GetReassignmentProgress 진행 중인 재배치 및 마지막 실행의 파티션별 상태 조회
+func GetReassignmentProgress(c *gin.Context) {
+	ongoing, err := listOngoingReassignments()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to list reassignments: %v", err),
+		})
+		return
+	}
+
+	reassignmentMu.Lock()
+	defer reassignmentMu.Unlock()
+
+	progress, err := buildReassignmentProgress(ongoing, activeReassignment)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to read partitions: %v", err),
+		})
+		return
+	}
+
+	completed := 0
+	for _, p := range progress {
+		if p.Status == "completed" {
+			completed++
+		}
+	}
+
+	response := gin.H{
+		"partitions":      progress,
+		"in_progress":     len(ongoing),
+		"completed":       completed,
+		"throttle_active": false,
+	}
+	if activeReassignment != nil {
+		response["started_at"] = activeReassignment.StartedAt
+		response["throttle_active"] = activeReassignment.throttled()
+		if activeReassignment.ThrottleError != "" {
+			response["throttle_error"] = activeReassignment.ThrottleError
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CancelReassignment 대시보드에서 실행한 재배치 중 진행 중인 파티션만 취소하고 스로틀 해제
+func CancelReassignment(c *gin.Context) {
+	ongoing, err := listOngoingReassignments()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to list reassignments: %v", err),
+		})
+		return
+	}
+
+	reassignmentMu.Lock()
+	defer reassignmentMu.Unlock()
+
+	// 다른 도구로 시작한 재배치는 건드리지 않음
+	ongoing = trackedReassignments(ongoing, activeReassignment)
+	if len(ongoing) == 0 {
+		response := gin.H{
+			"status":    "success",
+			"cancelled": 0,
+			"message":   "No reassignment started from the dashboard is in progress",
+		}
+		respondThrottleRemoval(c, response)
+		return
+	}
+
+	// kafka-go 클라이언트는 빈 레플리카 목록을 보내므로 취소(null)는 프로토콜 요청을 직접 사용
+	topics := make(map[string]*alterpartitionreassignments.RequestTopic)
+	for _, p := range ongoing {
+		topic := topics[p.Topic]
+		if topic == nil {
+			topic = &alterpartitionreassignments.RequestTopic{Name: p.Topic}
+			topics[p.Topic] = topic
+		}
+		topic.Partitions = append(topic.Partitions, alterpartitionreassignments.RequestPartition{
+			PartitionIndex: int32(p.Partition),
+			Replicas:       nil,
+		})
+	}
+
+	apiReq := &alterpartitionreassignments.Request{TimeoutMs: 30000}
+	for _, t := range topics {
+		apiReq.Topics = append(apiReq.Topics, *t)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	msg, err := kafka.DefaultTransport.RoundTrip(ctx, kafka.TCP(kafkaBrokers), apiReq)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to cancel reassignment: %v", err),
+		})
+		return
+	}
+
+	resp := msg.(*alterpartitionreassignments.Response)
+	if resp.ErrorCode != 0 {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to cancel reassignment: %v", kafka.Error(resp.ErrorCode)),
+		})
+		return
+	}
+
+	results := make([]gin.H, 0, len(ongoing))
+	for _, r := range resp.Results {
+		for _, p := range r.Partitions {
+			result := gin.H{
+				"topic":     r.Name,
+				"partition": p.PartitionIndex,
+				"status":    "cancelled",
+			}
+			if p.ErrorCode != 0 {
+				result["status"] = "failed"
+				result["error"] = kafka.Error(p.ErrorCode).Error()
+			}
+			results = append(results, result)
+		}
+	}
+
+	response := gin.H{
+		"status":    "success",
+		"cancelled": len(results),
+		"results":   results,
+		"message":   "Reassignment cancelled",
+	}
+	respondThrottleRemoval(c, response)
+}
+
+// respondThrottleRemoval 남은 스로틀을 해제한 뒤 응답 (해제 실패 시 207 partial)
+func respondThrottleRemoval(c *gin.Context, response gin.H) {
+	if activeReassignment == nil || !activeReassignment.throttled() {
+		c.JSON(http.StatusOK, response)
+		return
+	}
+	if err := removeReassignmentThrottle(activeReassignment); err != nil {
+		activeReassignment.ThrottleError = err.Error()
+		response["status"] = "partial"
+		response["throttle_error"] = err.Error()
+		c.JSON(http.StatusMultiStatus, response)
+		return
+	}
+	activeReassignment.ThrottleError = ""
+	c.JSON(http.StatusOK, response)
+}
+
+// watchReassignmentThrottle 실행한 재배치가 끝날 때까지 주기적으로 확인하고 끝나면 스로틀을 해제해 감사 기록에 남김
+func watchReassignmentThrottle(state *reassignmentState) {
+	ticker := time.NewTicker(reassignmentPollInterval)
+	defer ticker.Stop()
+
+	lastErr := ""
+	for range ticker.C {
+		ongoing, err := listOngoingReassignments()
+		if err != nil {
+			log.Printf("Reassignment: failed to list reassignments: %v", err)
+			continue
+		}
+		if len(trackedReassignments(ongoing, state)) > 0 {
+			continue
+		}
+
+		reassignmentMu.Lock()
+		// 취소나 다음 실행에서 이미 해제된 경우
+		if !state.throttled() {
+			reassignmentMu.Unlock()
+			return
+		}
+		params := map[string]interface{}{
+			"brokers": state.ThrottledBrokers,
+			"topics":  state.ThrottledTopics,
+		}
+		err = removeReassignmentThrottle(state)
+		if err != nil {
+			state.ThrottleError = err.Error()
+		} else {
+			state.ThrottleError = ""
+		}
+		reassignmentMu.Unlock()
+
+		record := AuditRecord{
+			Actor:  "system",
+			Action: "reassignment.throttle.remove",
+			Target: strings.Join(reassignmentTopics(state.Partitions), ","),
+			Params: params,
+			Status: http.StatusOK,
+			Result: "success",
+		}
+		if err == nil {
+			log.Printf("Reassignment: removed replication throttle")
+			RecordAudit(record)
+			return
+		}
+
+		// 같은 실패가 반복되면 감사 기록은 한 번만 남기고 다음 주기에 재시도
+		log.Printf("Reassignment: failed to remove replication throttle: %v", err)
+		if err.Error() != lastErr {
+			record.Status, record.Result, record.Error = http.StatusInternalServerError, "failure", err.Error()
+			RecordAudit(record)
+			lastErr = err.Error()
+		}
+	}
+}
+
+// trackedReassignments 진행 중인 재배치 중 state가 실행한 파티션만 추림
+func trackedReassignments(ongoing []ReassignmentProgress, state *reassignmentState) []ReassignmentProgress {
+	if state == nil {
+		return nil
+	}
+	keys := make(map[string]bool, len(state.Partitions))
+	for _, p := range state.Partitions {
+		keys[partitionKey(p.Topic, p.Partition)] = true
+	}
+	var tracked []ReassignmentProgress
+	for _, p := range ongoing {
+		if keys[partitionKey(p.Topic, p.Partition)] {
+			tracked = append(tracked, p)
+		}
+	}
+	return tracked
+}
+
+// planReassignment 기존 배치를 최대한 유지하면서 대상 브로커에 레플리카를 균등 분배
+func planReassignment(partitions []kafka.Partition, targets []int, racks map[int]string, rackAware bool) ([]PartitionReassignment, map[int]int, error) {
+	sort.Slice(partitions, func(i, j int) bool {
+		if partitions[i].Topic != partitions[j].Topic {
+			return partitions[i].Topic < partitions[j].Topic
+		}
+		return partitions[i].ID < partitions[j].ID
+	})
+
+	totalReplicas := 0
+	for _, p := range partitions {
+		if len(p.Replicas) > len(targets) {
+			return nil, nil, fmt.Errorf("%s-%d has replication factor %d but only %d target brokers were given",
+				p.Topic, p.ID, len(p.Replicas), len(targets))
+		}
+		totalReplicas += len(p.Replicas)
+	}
+
+	capacity := (totalReplicas + len(targets) - 1) / len(targets)
+	load := make(map[int]int, len(targets))
+	leaders := make(map[int]int, len(targets))
+	for _, id := range targets {
+		load[id] = 0
+	}
+
+	// 1단계: 대상 브로커에 이미 있는 레플리카는 용량과 랙 제약 안에서 유지
+	assigned := make([][]int, len(partitions))
+	for i, p := range partitions {
+		usedRacks := make(map[string]bool)
+		for _, id := range getReplicaIDs(p.Replicas) {
+			if _, ok := load[id]; !ok || load[id] >= capacity {
+				continue
+			}
+			if rackAware && usedRacks[racks[id]] {
+				continue
+			}
+			assigned[i] = append(assigned[i], id)
+			usedRacks[racks[id]] = true
+			load[id]++
+		}
+	}
+
+	// 2단계: 부족한 레플리카를 다른 랙, 부하가 낮은 브로커 순으로 채움
+	var plan []PartitionReassignment
+	for i, p := range partitions {
+		replicas := assigned[i]
+		for len(replicas) < len(p.Replicas) {
+			usedRacks := make(map[string]bool)
+			for _, id := range replicas {
+				usedRacks[racks[id]] = true
+			}
+
+			best := -1
+			for _, id := range targets {
+				if containsInt(replicas, id) {
+					continue
+				}
+				if best == -1 || betterReplicaCandidate(id, best, load, racks, usedRacks, rackAware) {
+					best = id
+				}
+			}
+			replicas = append(replicas, best)
+			load[best]++
+		}
+
+		// 선호 리더: 기존 리더를 유지할 수 있으면 유지, 아니면 리더 수가 가장 적은 브로커
+		current := getReplicaIDs(p.Replicas)
+		leader := -1
+		if len(current) > 0 && containsInt(replicas, current[0]) {
+			leader = current[0]
+		} else {
+			for _, id := range replicas {
+				if leader == -1 || leaders[id] < leaders[leader] {
+					leader = id
+				}
+			}
+		}
+		leaders[leader]++
+		replicas = moveToFront(replicas, leader)
+
+		if !equalInts(replicas, current) {
+			plan = append(plan, PartitionReassignment{
+				Topic:           p.Topic,
+				Partition:       p.ID,
+				CurrentReplicas: current,
+				Replicas:        replicas,
+			})
+		}
+	}
+
+	if plan == nil {
+		plan = []PartitionReassignment{}
+	}
+
+	return plan, load, nil
+}
+
+// betterReplicaCandidate 후보 a가 b보다 레플리카 배치에 적합한지 비교
+func betterReplicaCandidate(a, b int, load map[int]int, racks map[int]string, usedRacks map[string]bool, rackAware bool) bool {
+	if rackAware {
+		aNew, bNew := !usedRacks[racks[a]], !usedRacks[racks[b]]
+		if aNew != bNew {
+			return aNew
+		}
+	}
+	if load[a] != load[b] {
+		return load[a] < load[b]
+	}
+	return a < b
+}
+
+// listOngoingReassignments 클러스터에서 진행 중인 재배치 목록 조회
+func listOngoingReassignments() ([]ReassignmentProgress, error) {
+	client := newKafkaClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := client.ListPartitionReassignments(ctx, &kafka.ListPartitionReassignmentsRequest{
+		Timeout: 10 * time.Second,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+
+	var ongoing []ReassignmentProgress
+	for topic, t := range resp.Topics {
+		for _, p := range t.Partitions {
+			ongoing = append(ongoing, ReassignmentProgress{
+				Topic:            topic,
+				Partition:        p.PartitionIndex,
+				Status:           "in_progress",
+				Replicas:         p.Replicas,
+				AddingReplicas:   p.AddingReplicas,
+				RemovingReplicas: p.RemovingReplicas,
+			})
+		}
+	}
+
+	return ongoing, nil
+}
+
+// buildReassignmentProgress 진행 중 목록과 마지막 실행 계획을 합쳐 파티션별 상태 계산
+func buildReassignmentProgress(ongoing []ReassignmentProgress, state *reassignmentState) ([]ReassignmentProgress, error) {
+	byKey := make(map[string]*ReassignmentProgress)
+	var keys []string
+	for i := range ongoing {
+		key := partitionKey(ongoing[i].Topic, ongoing[i].Partition)
+		byKey[key] = &ongoing[i]
+		keys = append(keys, key)
+	}
+
+	if state != nil {
+		for _, p := range state.Partitions {
+			key := partitionKey(p.Topic, p.Partition)
+			if existing, ok := byKey[key]; ok {
+				existing.TargetReplicas = p.Replicas
+				continue
+			}
+			byKey[key] = &ReassignmentProgress{
+				Topic:          p.Topic,
+				Partition:      p.Partition,
+				TargetReplicas: p.Replicas,
+			}
+			keys = append(keys, key)
+		}
+	}
+
+	if len(keys) == 0 {
+		return []ReassignmentProgress{}, nil
+	}
+
+	var topics []string
+	for _, p := range byKey {
+		if !containsString(topics, p.Topic) {
+			topics = append(topics, p.Topic)
+		}
+	}
+
+	conn, err := kafka.Dial("tcp", kafkaBrokers)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	partitions, err := conn.ReadPartitions(topics...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range partitions {
+		progress, ok := byKey[partitionKey(p.Topic, p.ID)]
+		if !ok {
+			continue
+		}
+		progress.ISR = getReplicaIDs(p.Isr)
+		if progress.Status == "in_progress" {
+			continue
+		}
+		progress.Replicas = getReplicaIDs(p.Replicas)
+		if sameIntSet(progress.Replicas, progress.TargetReplicas) {
+			progress.Status = "completed"
+		} else {
+			progress.Status = "not_started"
+		}
+	}
+
+	sort.Strings(keys)
+	result := make([]ReassignmentProgress, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, *byKey[key])
+	}
+
+	return result, nil
+}
+
+// applyReassignmentThrottle 재배치 대상 브로커와 토픽에 복제 스로틀 설정
+func applyReassignmentThrottle(state *reassignmentState) error {
+	leaderReplicas := make(map[string][]string)
+	followerReplicas := make(map[string][]string)
+	var brokers []int
+
+	for _, p := range state.Partitions {
+		for _, id := range p.CurrentReplicas {
+			leaderReplicas[p.Topic] = append(leaderReplicas[p.Topic], fmt.Sprintf("%d:%d", p.Partition, id))
+			if !containsInt(brokers, id) {
+				brokers = append(brokers, id)
+			}
+		}
+		for _, id := range p.Replicas {
+			if containsInt(p.CurrentReplicas, id) {
+				continue
+			}
+			followerReplicas[p.Topic] = append(followerReplicas[p.Topic], fmt.Sprintf("%d:%d", p.Partition, id))
+			if !containsInt(brokers, id) {
+				brokers = append(brokers, id)
+			}
+		}
+	}
+
+	rate := strconv.FormatInt(state.Throttle, 10)
+	for _, id := range brokers {
+		err := incrementalAlterConfigs(kafka.ResourceTypeBroker, strconv.Itoa(id), []ConfigChange{
+			{Name: leaderThrottleRate, Value: rate},
+			{Name: followerThrottleRate, Value: rate},
+		}, false)
+		if err != nil {
+			return fmt.Errorf("broker %d: %w", id, err)
+		}
+		state.ThrottledBrokers = append(state.ThrottledBrokers, id)
+	}
+
+	for topic, leaders := range leaderReplicas {
+		changes := []ConfigChange{{Name: leaderThrottledReplicas, Value: strings.Join(leaders, ",")}}
+		if followers := followerReplicas[topic]; len(followers) > 0 {
+			changes = append(changes, ConfigChange{Name: followerThrottledReplicas, Value: strings.Join(followers, ",")})
+		}
+		if err := incrementalAlterConfigs(kafka.ResourceTypeTopic, topic, changes, false); err != nil {
+			return fmt.Errorf("topic %s: %w", topic, err)
+		}
+		state.ThrottledTopics = append(state.ThrottledTopics, topic)
+	}
+
+	return nil
+}
+
+// removeReassignmentThrottle 적용했던 복제 스로틀 설정 제거
+// 개별 실패가 있어도 나머지는 계속 해제하고, 실패한 브로커/토픽은 state에 남겨 재시도할 수 있게 함
+func removeReassignmentThrottle(state *reassignmentState) error {
+	var errs []error
+
+	var brokers []int
+	for _, id := range state.ThrottledBrokers {
+		err := incrementalAlterConfigs(kafka.ResourceTypeBroker, strconv.Itoa(id), []ConfigChange{
+			{Name: leaderThrottleRate, Op: "delete"},
+			{Name: followerThrottleRate, Op: "delete"},
+		}, false)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("broker %d: %w", id, err))
+			brokers = append(brokers, id)
+		}
+	}
+
+	var topics []string
+	for _, topic := range state.ThrottledTopics {
+		err := incrementalAlterConfigs(kafka.ResourceTypeTopic, topic, []ConfigChange{
+			{Name: leaderThrottledReplicas, Op: "delete"},
+			{Name: followerThrottledReplicas, Op: "delete"},
+		}, false)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("topic %s: %w", topic, err))
+			topics = append(topics, topic)
+		}
+	}
+
+	state.ThrottledBrokers = brokers
+	state.ThrottledTopics = topics
+	return errors.Join(errs...)
+}
+
+// reassignmentTopics 재배치 항목에 포함된 토픽 목록
+func reassignmentTopics(partitions []PartitionReassignment) []string {
+	var topics []string
+	for _, p := range partitions {
+		if !containsString(topics, p.Topic) {
+			topics = append(topics, p.Topic)
+		}
+	}
+	return topics
+}
+
+// hasRacks 대상 브로커 중 랙 정보가 설정된 브로커가 있는지 확인
+func hasRacks(racks map[int]string, targets []int) bool {
+	for _, id := range targets {
+		if racks[id] != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// partitionKey 토픽-파티션 식별 키
+func partitionKey(topic string, partition int) string {
+	return fmt.Sprintf("%s-%d", topic, partition)
+}
+
+// moveToFront 지정한 값을 슬라이스 맨 앞으로 이동
+func moveToFront(ids []int, id int) []int {
+	result := []int{id}
+	for _, v := range ids {
+		if v != id {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// containsInt 정수 슬라이스 포함 여부
+func containsInt(items []int, target int) bool {
+	for _, item := range items {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
+
+// equalInts 순서까지 같은지 비교
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// sameIntSet 순서와 무관하게 같은 원소인지 비교
+func sameIntSet(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, v := range a {
+		if !containsInt(b, v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// recordNulls 레코드의 키/값 null 여부
+type recordNulls struct {
+	key   bool
+	value bool
+}
+
+// nullResolver 소비한 메시지의 키/값이 null인지 빈 값인지 판별
+//
+// kafka-go Reader는 null과 길이 0인 키/값을 모두 nil로 돌려주기 때문에, 키나 값이
+// 비어 있는 메시지는 같은 오프셋부터 Fetch API로 다시 읽어 확인한다. 한 번 읽은
+// 레코드 배치의 결과는 파티션별로 캐시해 연속된 메시지는 추가 요청 없이 판별한다.
+type nullResolver struct {
+	client *kafka.Client
+	topic  string
+	cache  map[int]map[int64]recordNulls
+}
+
+// newNullResolver 토픽별 null 판별기 생성
+func newNullResolver(topic string) *nullResolver {
+	return &nullResolver{
+		client: newKafkaClient(),
+		topic:  topic,
+		cache:  make(map[int]map[int64]recordNulls),
+	}
+}
+
+// consumed 소비한 메시지를 응답 구조로 변환 (null 키/값은 JSON null)
+func (r *nullResolver) consumed(msg kafka.Message) ConsumedMessage {
+	consumed := ConsumedMessage{
+		Topic:     msg.Topic,
+		Partition: msg.Partition,
+		Offset:    msg.Offset,
+		Timestamp: msg.Time,
+	}
+
+	var nulls recordNulls
+	if len(msg.Key) == 0 || len(msg.Value) == 0 {
+		nulls = r.lookup(msg.Partition, msg.Offset)
+	}
+	if len(msg.Key) > 0 || !nulls.key {
+		key := string(msg.Key)
+		consumed.Key = &key
+	}
+	if len(msg.Value) > 0 || !nulls.value {
+		value := string(msg.Value)
+		consumed.Value = &value
+	}
+	return consumed
+}
+
+// lookup 오프셋의 null 여부 (확인할 수 없으면 kafka-go와 같이 null로 간주)
+func (r *nullResolver) lookup(partition int, offset int64) recordNulls {
+	if nulls, ok := r.cache[partition][offset]; ok {
+		return nulls
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := r.client.Fetch(ctx, &kafka.FetchRequest{
+		Topic:     r.topic,
+		Partition: partition,
+		Offset:    offset,
+		MinBytes:  1,
+		MaxBytes:  1e6, // 1MB
+		MaxWait:   100 * time.Millisecond,
+	})
+	if err == nil {
+		err = resp.Error
+	}
+	if err != nil {
+		log.Printf("Failed to fetch %s[%d]@%d to check null key/value: %v", r.topic, partition, offset, err)
+		return recordNulls{key: true, value: true}
+	}
+
+	// 이전 배치 결과는 더 이상 필요 없으므로 교체
+	batch := make(map[int64]recordNulls)
+	for {
+		rec, err := resp.Records.ReadRecord()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				log.Printf("Failed to read %s[%d] records: %v", r.topic, partition, err)
+			}
+			break
+		}
+		batch[rec.Offset] = recordNulls{key: rec.Key == nil, value: rec.Value == nil}
+	}
+	r.cache[partition] = batch
+
+	if nulls, ok := batch[offset]; ok {
+		return nulls
+	}
+	return recordNulls{key: true, value: true}
+}
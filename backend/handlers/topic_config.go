@@ -0,0 +1,425 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
+)
+
+// ConfigEntry 설정 항목 정보
+type ConfigEntry struct {
+	Name      string          `json:"name"`
+	Value     string          `json:"value"`
+	Source    string          `json:"source"`
+	IsDefault bool            `json:"is_default"`
+	ReadOnly  bool            `json:"read_only"`
+	Sensitive bool            `json:"sensitive"`
+	Synonyms  []ConfigSynonym `json:"synonyms,omitempty"`
+}
+
+// ConfigSynonym 설정 값이 상속된 경로 (우선순위 순)
+type ConfigSynonym struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// ConfigChange 설정 변경 항목
+type ConfigChange struct {
+	Name  string `json:"name" binding:"required"`
+	Value string `json:"value"`
+	// Op set(기본값), delete, append, subtract
+	Op string `json:"op"`
+}
+
+// AlterConfigRequest 설정 변경 요청
+type AlterConfigRequest struct {
+	Configs []ConfigChange `json:"configs" binding:"required,min=1,dive"`
+	DryRun  bool           `json:"dryRun"`
+}
+
+// ConfigChangePreview 설정 변경 미리보기
+type ConfigChangePreview struct {
+	Name     string  `json:"name"`
+	Op       string  `json:"op"`
+	OldValue string  `json:"old_value"`
+	NewValue *string `json:"new_value"` // delete 시 기본값으로 돌아가므로 null
+	Source   string  `json:"source"`
+}
+
+// ConfigValidationError 설정 검증 오류
+type ConfigValidationError struct {
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+// configSourceNames Kafka ConfigSource 코드별 이름
+var configSourceNames = map[int8]string{
+	0: "unknown",
+	1: "dynamic_topic",
+	2: "dynamic_broker",
+	3: "dynamic_default_broker",
+	4: "static_broker",
+	5: "default",
+	6: "dynamic_broker_logger",
+}
+
+// configOperations 요청의 op 문자열과 Kafka 연산 매핑
+var configOperations = map[string]kafka.ConfigOperation{
+	"set":      kafka.ConfigOperationSet,
+	"delete":   kafka.ConfigOperationDelete,
+	"append":   kafka.ConfigOperationAppend,
+	"subtract": kafka.ConfigOperationSubtract,
+}
+
+// GetTopicConfig 토픽 설정 조회
+func GetTopicConfig(c *gin.Context) {
+	topicName := c.Param("name")
+
+	entries, err := describeConfigs(kafka.ResourceTypeTopic, topicName)
+	if err != nil {
+		if errors.Is(err, kafka.UnknownTopicOrPartition) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Topic not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to describe topic config: %v", err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"topic":   topicName,
+		"configs": entries,
+		"count":   len(entries),
+	})
+}
+
+// AlterTopicConfig 토픽 설정 증분 변경 (dryRun 지원)
+func AlterTopicConfig(c *gin.Context) {
+	topicName := c.Param("name")
+
+	var req AlterConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	current, err := describeConfigs(kafka.ResourceTypeTopic, topicName)
+	if err != nil {
+		if errors.Is(err, kafka.UnknownTopicOrPartition) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Topic not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to describe topic config: %v", err),
+		})
+		return
+	}
+
+	previews, validationErrors := previewConfigChanges(current, req.Configs)
+	if len(validationErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":  "Invalid config changes",
+			"errors": validationErrors,
+		})
+		return
+	}
+
+	if topicPolicy != nil {
+		replicationFactor := 0
+		if partitions, err := readPartitions(topicName); err == nil && len(partitions) > 0 {
+			replicationFactor = len(partitions[0].Replicas)
+		}
+		violations, err := topicPolicy.checkConfigs(replicationFactor, topicConfigsAfter(current, previews))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": fmt.Sprintf("Failed to check topic policy: %v", err),
+			})
+			return
+		}
+		if len(violations) > 0 {
+			respondPolicyViolations(c, violations)
+			return
+		}
+	}
+
+	if err := incrementalAlterConfigs(kafka.ResourceTypeTopic, topicName, req.Configs, req.DryRun); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("Failed to alter topic config: %v", err),
+		})
+		return
+	}
+
+	status := "success"
+	message := "Topic config altered successfully"
+	if req.DryRun {
+		status = "validated"
+		message = "Config changes are valid (dry run, nothing applied)"
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  status,
+		"topic":   topicName,
+		"dry_run": req.DryRun,
+		"changes": previews,
+		"message": message,
+	})
+}
+
+// describeConfigs 리소스(토픽/브로커)의 전체 설정 조회
+func describeConfigs(resourceType kafka.ResourceType, resourceName string) ([]ConfigEntry, error) {
+	client := newKafkaClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := client.DescribeConfigs(ctx, &kafka.DescribeConfigsRequest{
+		Resources: []kafka.DescribeConfigRequestResource{
+			{
+				ResourceType: resourceType,
+				ResourceName: resourceName,
+			},
+		},
+		IncludeSynonyms: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Resources) == 0 {
+		return nil, fmt.Errorf("empty response for %s", resourceName)
+	}
+
+	resource := resp.Resources[0]
+	if resource.Error != nil {
+		return nil, resource.Error
+	}
+
+	entries := make([]ConfigEntry, 0, len(resource.ConfigEntries))
+	for _, e := range resource.ConfigEntries {
+		var synonyms []ConfigSynonym
+		for _, s := range e.ConfigSynonyms {
+			synonyms = append(synonyms, ConfigSynonym{
+				Name:   s.ConfigName,
+				Value:  s.ConfigValue,
+				Source: configSourceName(s.ConfigSource),
+			})
+		}
+
+		entries = append(entries, ConfigEntry{
+			Name:      e.ConfigName,
+			Value:     e.ConfigValue,
+			Source:    configSourceName(e.ConfigSource),
+			IsDefault: e.IsDefault || e.ConfigSource == 5,
+			ReadOnly:  e.ReadOnly,
+			Sensitive: e.IsSensitive,
+			Synonyms:  synonyms,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+
+	return entries, nil
+}
+
+// incrementalAlterConfigs 리소스 설정 증분 변경 (validateOnly면 브로커 검증만 수행)
+func incrementalAlterConfigs(resourceType kafka.ResourceType, resourceName string, changes []ConfigChange, validateOnly bool) error {
+	configs := make([]kafka.IncrementalAlterConfigsRequestConfig, 0, len(changes))
+	for _, change := range changes {
+		configs = append(configs, kafka.IncrementalAlterConfigsRequestConfig{
+			Name:            change.Name,
+			Value:           change.Value,
+			ConfigOperation: configOperations[normalizeConfigOp(change.Op)],
+		})
+	}
+
+	client := newKafkaClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := client.IncrementalAlterConfigs(ctx, &kafka.IncrementalAlterConfigsRequest{
+		Resources: []kafka.IncrementalAlterConfigsRequestResource{
+			{
+				ResourceType: resourceType,
+				ResourceName: resourceName,
+				Configs:      configs,
+			},
+		},
+		ValidateOnly: validateOnly,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, r := range resp.Resources {
+		if r.Error != nil {
+			return r.Error
+		}
+	}
+
+	return nil
+}
+
+// previewConfigChanges 현재 설정 기준으로 변경 내용을 검증하고 미리보기 생성
+func previewConfigChanges(current []ConfigEntry, changes []ConfigChange) ([]ConfigChangePreview, []ConfigValidationError) {
+	entries := make(map[string]ConfigEntry, len(current))
+	for _, e := range current {
+		entries[e.Name] = e
+	}
+
+	var previews []ConfigChangePreview
+	var validationErrors []ConfigValidationError
+	seen := make(map[string]bool)
+
+	for _, change := range changes {
+		op := normalizeConfigOp(change.Op)
+
+		if _, ok := configOperations[op]; !ok {
+			validationErrors = append(validationErrors, ConfigValidationError{
+				Name:  change.Name,
+				Error: fmt.Sprintf("unknown op %q (expected set, delete, append or subtract)", change.Op),
+			})
+			continue
+		}
+
+		if seen[change.Name] {
+			validationErrors = append(validationErrors, ConfigValidationError{
+				Name:  change.Name,
+				Error: "config listed more than once",
+			})
+			continue
+		}
+		seen[change.Name] = true
+
+		entry, ok := entries[change.Name]
+		if !ok {
+			validationErrors = append(validationErrors, ConfigValidationError{
+				Name:  change.Name,
+				Error: "unknown config",
+			})
+			continue
+		}
+
+		if entry.ReadOnly {
+			validationErrors = append(validationErrors, ConfigValidationError{
+				Name:  change.Name,
+				Error: "config is read-only",
+			})
+			continue
+		}
+
+		if (op == "append" || op == "subtract") && change.Value == "" {
+			validationErrors = append(validationErrors, ConfigValidationError{
+				Name:  change.Name,
+				Error: fmt.Sprintf("value is required for %s", op),
+			})
+			continue
+		}
+
+		previews = append(previews, ConfigChangePreview{
+			Name:     change.Name,
+			Op:       op,
+			OldValue: entry.Value,
+			NewValue: previewConfigValue(entry.Value, op, change.Value),
+			Source:   entry.Source,
+		})
+	}
+
+	return previews, validationErrors
+}
+
+// previewConfigValue 연산 적용 후 예상 값 계산 (리스트형 설정은 콤마 구분)
+func previewConfigValue(oldValue, op, value string) *string {
+	var newValue string
+
+	switch op {
+	case "delete":
+		return nil
+	case "append":
+		items := splitConfigList(oldValue)
+		for _, v := range splitConfigList(value) {
+			if !containsString(items, v) {
+				items = append(items, v)
+			}
+		}
+		newValue = strings.Join(items, ",")
+	case "subtract":
+		removed := splitConfigList(value)
+		var items []string
+		for _, v := range splitConfigList(oldValue) {
+			if !containsString(removed, v) {
+				items = append(items, v)
+			}
+		}
+		newValue = strings.Join(items, ",")
+	default:
+		newValue = value
+	}
+
+	return &newValue
+}
+
+// normalizeConfigOp op 문자열 정규화 (빈 값은 set)
+func normalizeConfigOp(op string) string {
+	op = strings.ToLower(strings.TrimSpace(op))
+	if op == "" {
+		return "set"
+	}
+	return op
+}
+
+// configSourceName ConfigSource 코드를 이름으로 변환
+func configSourceName(source int8) string {
+	if name, ok := configSourceNames[source]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// toConfigEntries map 형태의 설정을 kafka.ConfigEntry 목록으로 변환
+func toConfigEntries(configs map[string]string) []kafka.ConfigEntry {
+	if len(configs) == 0 {
+		return nil
+	}
+
+	entries := make([]kafka.ConfigEntry, 0, len(configs))
+	for name, value := range configs {
+		entries = append(entries, kafka.ConfigEntry{
+			ConfigName:  name,
+			ConfigValue: value,
+		})
+	}
+	return entries
+}
+
+// splitConfigList 콤마 구분 설정 값을 리스트로 분리
+func splitConfigList(value string) []string {
+	var items []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			items = append(items, v)
+		}
+	}
+	return items
+}
+
+// containsString 문자열 슬라이스 포함 여부
+func containsString(items []string, target string) bool {
+	for _, item := range items {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,411 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	// deleteConfirmTTL dry-run으로 발급한 삭제 확인 토큰 유효 시간
+	deleteConfirmTTL = 5 * time.Minute
+	// recentProduceWindow 이 시간 안에 기록된 메시지가 있으면 최근 사용 중으로 판단
+	recentProduceWindow = time.Hour
+	// defaultArchivePrefix soft 삭제 시 보관 토픽 이름 접두사 기본값
+	defaultArchivePrefix = "archive."
+	// archiveCopyBatchSize 보관 토픽으로 복사할 때 한 번에 쓰는 메시지 수
+	archiveCopyBatchSize = 500
+	// archiveReadIdleTimeout 복사 중 새 메시지가 없으면 파티션 끝으로 간주하는 시간
+	archiveReadIdleTimeout = 10 * time.Second
+)
+
+// deleteConfirmation 발급된 삭제 확인 토큰
+type deleteConfirmation struct {
+	topic     string
+	expiresAt time.Time
+}
+
+var (
+	deleteConfirmMu sync.Mutex
+	// deleteConfirmations 토큰별 확인 정보 (한 번 사용하면 제거)
+	deleteConfirmations = make(map[string]deleteConfirmation)
+)
+
+// TopicConsumerGroup 삭제 대상 토픽을 사용하는 Consumer Group
+type TopicConsumerGroup struct {
+	GroupID string `json:"group_id"`
+	State   string `json:"state"`
+	// Members 토픽 파티션을 할당받은 멤버 수
+	Members int `json:"members"`
+	// CommittedPartitions 커밋된 오프셋이 있는 파티션 수
+	CommittedPartitions int `json:"committed_partitions"`
+}
+
+// TopicDeletePreview 토픽 삭제 dry-run 결과
+type TopicDeletePreview struct {
+	Topic            string               `json:"topic"`
+	Partitions       int                  `json:"partitions"`
+	Messages         int64                `json:"messages"`
+	LastProducedAt   *time.Time           `json:"last_produced_at"`
+	RecentlyProduced bool                 `json:"recently_produced"`
+	ConsumerGroups   []TopicConsumerGroup `json:"consumer_groups"`
+	Warnings         []string             `json:"warnings"`
+	ConfirmToken     string               `json:"confirm_token"`
+	ExpiresAt        time.Time            `json:"expires_at"`
+	// ArchiveTopic soft 삭제 시 생성될 보관 토픽 이름 (예시)
+	ArchiveTopic string `json:"archive_topic"`
+}
+
+// protectedTopicReason 토픽/레코드 삭제가 금지된 토픽이면 이유 반환 (내부 토픽과 정책의 protectedTopics)
+func protectedTopicReason(topic string) string {
+	if strings.HasPrefix(topic, "__") {
+		return "internal topic"
+	}
+	if topicPolicy != nil {
+		for i, re := range topicPolicy.protectedRegexps {
+			if re.MatchString(topic) {
+				return fmt.Sprintf("matches protected pattern %s", topicPolicy.ProtectedTopics[i])
+			}
+		}
+	}
+	return ""
+}
+
+// previewTopicDelete 삭제 영향(Consumer Group, 최근 produce)을 확인하고 확인 토큰 발급
+func previewTopicDelete(topic string, partitions []kafka.Partition) (*TopicDeletePreview, error) {
+	preview := &TopicDeletePreview{
+		Topic:          topic,
+		Partitions:     len(partitions),
+		ConsumerGroups: []TopicConsumerGroup{},
+		Warnings:       []string{},
+		ArchiveTopic:   archiveTopicName(topic, time.Now()),
+	}
+
+	for _, p := range partitions {
+		first, last := getPartitionOffsets(topic, p.ID)
+		if first < 0 || last <= first {
+			continue
+		}
+		preview.Messages += last - first
+
+		if t, ok := lastMessageTime(topic, p.ID, last); ok {
+			if preview.LastProducedAt == nil || t.After(*preview.LastProducedAt) {
+				preview.LastProducedAt = &t
+			}
+		}
+	}
+
+	if preview.LastProducedAt != nil && time.Since(*preview.LastProducedAt) < recentProduceWindow {
+		preview.RecentlyProduced = true
+		preview.Warnings = append(preview.Warnings, fmt.Sprintf(
+			"messages were produced within the last %s (last at %s)",
+			recentProduceWindow, preview.LastProducedAt.Format(time.RFC3339)))
+	}
+
+	groups, err := topicConsumerGroups(topic, len(partitions))
+	if err != nil {
+		return nil, fmt.Errorf("list consumer groups: %w", err)
+	}
+	preview.ConsumerGroups = groups
+	for _, g := range groups {
+		if g.Members > 0 {
+			preview.Warnings = append(preview.Warnings, fmt.Sprintf(
+				"consumer group %s has %d active member(s) assigned to this topic", g.GroupID, g.Members))
+		}
+	}
+
+	token, err := issueDeleteToken(topic)
+	if err != nil {
+		return nil, err
+	}
+	preview.ConfirmToken = token
+	preview.ExpiresAt = time.Now().Add(deleteConfirmTTL)
+
+	return preview, nil
+}
+
+// topicConsumerGroups 토픽을 할당받았거나 오프셋을 커밋한 Consumer Group 조회
+func topicConsumerGroups(topic string, partitionCount int) ([]TopicConsumerGroup, error) {
+	client := newKafkaClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	listed, err := client.ListGroups(ctx, &kafka.ListGroupsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	if listed.Error != nil {
+		return nil, listed.Error
+	}
+	if len(listed.Groups) == 0 {
+		return []TopicConsumerGroup{}, nil
+	}
+
+	ids := make([]string, 0, len(listed.Groups))
+	for _, g := range listed.Groups {
+		ids = append(ids, g.GroupID)
+	}
+
+	described, err := client.DescribeGroups(ctx, &kafka.DescribeGroupsRequest{GroupIDs: ids})
+	if err != nil {
+		return nil, err
+	}
+
+	groups := []TopicConsumerGroup{}
+	for _, g := range described.Groups {
+		if g.Error != nil {
+			continue
+		}
+
+		group := TopicConsumerGroup{GroupID: g.GroupID, State: g.GroupState}
+		for _, m := range g.Members {
+			for _, t := range m.MemberAssignments.Topics {
+				if t.Topic == topic {
+					group.Members++
+					break
+				}
+			}
+		}
+
+		offsets, err := client.OffsetFetch(ctx, &kafka.OffsetFetchRequest{
+			GroupID: g.GroupID,
+			Topics:  map[string][]int{topic: partitionRange(partitionCount)},
+		})
+		if err == nil {
+			for _, p := range offsets.Topics[topic] {
+				if p.Error == nil && p.CommittedOffset >= 0 {
+					group.CommittedPartitions++
+				}
+			}
+		}
+
+		if group.Members > 0 || group.CommittedPartitions > 0 {
+			groups = append(groups, group)
+		}
+	}
+
+	return groups, nil
+}
+
+// lastMessageTime 파티션 마지막 메시지의 타임스탬프
+func lastMessageTime(topic string, partition int, last int64) (time.Time, bool) {
+	conn, err := kafka.DialLeader(context.Background(), "tcp", kafkaBrokers, topic, partition)
+	if err != nil {
+		return time.Time{}, false
+	}
+	defer conn.Close()
+
+	if _, err := conn.Seek(last-1, kafka.SeekAbsolute); err != nil {
+		return time.Time{}, false
+	}
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	batch := conn.ReadBatch(1, 10e6)
+	defer batch.Close()
+
+	var latest time.Time
+	for {
+		msg, err := batch.ReadMessage()
+		if err != nil {
+			break
+		}
+		if msg.Time.After(latest) {
+			latest = msg.Time
+		}
+		if msg.Offset >= last-1 {
+			break
+		}
+	}
+
+	return latest, !latest.IsZero()
+}
+
+// issueDeleteToken 토픽 삭제 확인 토큰 발급
+func issueDeleteToken(topic string) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(buf)
+
+	deleteConfirmMu.Lock()
+	defer deleteConfirmMu.Unlock()
+
+	now := time.Now()
+	for t, confirmation := range deleteConfirmations {
+		if now.After(confirmation.expiresAt) {
+			delete(deleteConfirmations, t)
+		}
+	}
+	deleteConfirmations[token] = deleteConfirmation{topic: topic, expiresAt: now.Add(deleteConfirmTTL)}
+
+	return token, nil
+}
+
+// consumeDeleteToken 토큰이 해당 토픽에 대해 유효하면 사용 처리
+func consumeDeleteToken(topic, token string) bool {
+	deleteConfirmMu.Lock()
+	defer deleteConfirmMu.Unlock()
+
+	confirmation, ok := deleteConfirmations[token]
+	if !ok || confirmation.topic != topic || time.Now().After(confirmation.expiresAt) {
+		return false
+	}
+	delete(deleteConfirmations, token)
+	return true
+}
+
+// archiveTopicName soft 삭제 시 보관 토픽 이름
+func archiveTopicName(topic string, at time.Time) string {
+	prefix := defaultArchivePrefix
+	if topicPolicy != nil && topicPolicy.ArchivePrefix != "" {
+		prefix = topicPolicy.ArchivePrefix
+	}
+	return fmt.Sprintf("%s%s.%s", prefix, topic, at.UTC().Format("20060102150405"))
+}
+
+// archiveTopic 원본과 같은 파티션 수/복제 계수/토픽 설정으로 보관 토픽을 만들고 모든 메시지를 파티션 그대로 복사
+func archiveTopic(ctx context.Context, topic string, partitions []kafka.Partition) (string, int64, error) {
+	archive := archiveTopicName(topic, time.Now())
+
+	current, err := describeConfigs(kafka.ResourceTypeTopic, topic)
+	if err != nil {
+		return "", 0, err
+	}
+	configs := make(map[string]string)
+	for _, e := range current {
+		if e.Source == "dynamic_topic" && !e.Sensitive {
+			configs[e.Name] = e.Value
+		}
+	}
+
+	replicationFactor := 1
+	if len(partitions) > 0 {
+		replicationFactor = len(partitions[0].Replicas)
+	}
+
+	// 보관 토픽도 일반 토픽 생성과 같은 정책을 적용
+	violations := topicPolicy.checkTopic(archive, len(partitions), replicationFactor)
+	configViolations, err := topicPolicy.checkConfigs(replicationFactor, configs)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to check topic policy: %w", err)
+	}
+	if violations = append(violations, configViolations...); len(violations) > 0 {
+		return "", 0, &policyViolationError{topic: archive, violations: violations}
+	}
+
+	client := newKafkaClient()
+	resp, err := client.CreateTopics(ctx, &kafka.CreateTopicsRequest{
+		Topics: []kafka.TopicConfig{{
+			Topic:             archive,
+			NumPartitions:     len(partitions),
+			ReplicationFactor: replicationFactor,
+			ConfigEntries:     toConfigEntries(configs),
+		}},
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	if err := resp.Errors[archive]; err != nil {
+		return "", 0, err
+	}
+
+	// 원본 파티션 번호를 그대로 사용
+	writer := &kafka.Writer{
+		Addr:  kafka.TCP(kafkaBrokers),
+		Topic: archive,
+		Balancer: kafka.BalancerFunc(func(msg kafka.Message, _ ...int) int {
+			return msg.Partition
+		}),
+		RequiredAcks: kafka.RequireAll,
+		BatchSize:    archiveCopyBatchSize,
+	}
+	defer writer.Close()
+
+	var copied int64
+	for _, p := range partitions {
+		n, err := copyPartition(ctx, writer, topic, p.ID)
+		copied += n
+		if err != nil {
+			return archive, copied, fmt.Errorf("copy partition %d: %w", p.ID, err)
+		}
+	}
+
+	return archive, copied, nil
+}
+
+// copyPartition 파티션의 현재 메시지를 키, 헤더, 타임스탬프를 유지한 채 복사
+func copyPartition(ctx context.Context, writer *kafka.Writer, topic string, partition int) (int64, error) {
+	first, last := getPartitionOffsets(topic, partition)
+	if first < 0 || last <= first {
+		return 0, nil
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:   []string{kafkaBrokers},
+		Topic:     topic,
+		Partition: partition,
+		MinBytes:  1,
+		MaxBytes:  10e6,
+	})
+	defer reader.Close()
+
+	if err := reader.SetOffset(first); err != nil {
+		return 0, err
+	}
+
+	var copied int64
+	batch := make([]kafka.Message, 0, archiveCopyBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := writer.WriteMessages(ctx, batch...); err != nil {
+			return err
+		}
+		copied += int64(len(batch))
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		// 압축(compaction)이나 트랜잭션 마커로 마지막 오프셋이 비어 있을 수 있어 대기 시간을 둠
+		readCtx, cancel := context.WithTimeout(ctx, archiveReadIdleTimeout)
+		msg, err := reader.ReadMessage(readCtx)
+		cancel()
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+				break
+			}
+			return copied, err
+		}
+
+		batch = append(batch, kafka.Message{
+			Partition: partition,
+			Key:       msg.Key,
+			Value:     msg.Value,
+			Headers:   msg.Headers,
+			Time:      msg.Time,
+		})
+		if len(batch) >= archiveCopyBatchSize {
+			if err := flush(); err != nil {
+				return copied, err
+			}
+		}
+
+		// 삭제 직전 시점의 마지막 오프셋까지만 복사
+		if msg.Offset >= last-1 {
+			break
+		}
+	}
+
+	return copied, flush()
+}
@@ -0,0 +1,597 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"backend/kafkaext"
+
+	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/protocol"
+	"github.com/segmentio/kafka-go/protocol/initproducerid"
+)
+
+const (
+	// txnDefaultTimeout 트랜잭션 기본 제한 시간 (브로커 transaction.max.timeout.ms 이하여야 함)
+	txnDefaultTimeout = time.Minute
+	// txnMaxAttempts 코디네이터가 바쁘거나 일시적인 오류일 때 재시도 횟수
+	txnMaxAttempts = 10
+	// produceMaxAttempts 멱등 전송 재시도 횟수 (같은 시퀀스로 다시 보내 중복 없이 재시도)
+	produceMaxAttempts = 3
+	// transactionalIDPrefix 대시보드가 사용하는 트랜잭션 ID 접두사 (애플리케이션의 트랜잭션 ID를 fencing하지 않도록 강제)
+	transactionalIDPrefix = "kafka-monitor-"
+)
+
+// TransactionRequest 트랜잭션 전송 요청 (여러 토픽의 메시지를 원자적으로 전송)
+type TransactionRequest struct {
+	// TransactionalID 생략 시 요청마다 새로 생성 (같은 ID의 이전 Producer는 fencing됨)
+	// transactionalIDPrefix로 시작하지 않으면 접두사를 붙여 사용
+	TransactionalID string `json:"transactionalId"`
+	// Action commit(기본) 또는 abort
+	Action string `json:"action"`
+	// TimeoutMs 트랜잭션 제한 시간 (기본 60000)
+	TimeoutMs int                  `json:"timeoutMs"`
+	Messages  []TransactionMessage `json:"messages" binding:"required"`
+	ProducerOptions
+}
+
+// TransactionMessage 트랜잭션에 포함할 메시지 (메시지마다 토픽 지정)
+type TransactionMessage struct {
+	Topic string `json:"topic"`
+	BatchMessage
+}
+
+// ProduceTransaction 트랜잭션 전송 핸들러
+//
+// 모든 메시지를 하나의 트랜잭션으로 보내고 action에 따라 커밋하거나 중단한다.
+// 전송에 실패한 메시지가 하나라도 있으면 커밋하지 않고 중단한다.
+func ProduceTransaction(c *gin.Context) {
+	var req TransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.Action == "" {
+		req.Action = "commit"
+	}
+	timeout := txnDefaultTimeout
+	if req.TimeoutMs > 0 {
+		timeout = time.Duration(req.TimeoutMs) * time.Millisecond
+	}
+	switch {
+	case req.Action != "commit" && req.Action != "abort":
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown action %q (expected commit or abort)", req.Action)})
+		return
+	case len(req.Messages) == 0:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "messages must not be empty"})
+		return
+	case req.TimeoutMs < 0:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "timeoutMs must not be negative"})
+		return
+	case req.Acks != "" && req.Acks != "all":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "transactions require acks=all"})
+		return
+	}
+	compression, err := req.ProducerOptions.compression()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// 토픽별로 메시지 분류 (요청에 처음 나온 순서 유지)
+	messages := make([]kafka.Message, len(req.Messages))
+	var topics []string
+	byTopic := make(map[string][]int)
+	for i, m := range req.Messages {
+		if m.Topic == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("messages[%d]: topic is required", i)})
+			return
+		}
+		msg, err := m.message(req.messageTime())
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("messages[%d]: %v", i, err)})
+			return
+		}
+		msg.Topic = m.Topic
+		msg.WriterData = i
+		messages[i] = msg
+
+		if _, ok := byTopic[m.Topic]; !ok {
+			topics = append(topics, m.Topic)
+		}
+		byTopic[m.Topic] = append(byTopic[m.Topic], i)
+	}
+
+	// 파티션 배정 (메시지에 지정한 파티션 우선)
+	assignments := make(map[string]map[int][]int, len(topics))
+	for _, topic := range topics {
+		balancer, err := req.ProducerOptions.balancer()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		fixed := make(map[int]int)
+		for _, i := range byTopic[topic] {
+			if p := req.Messages[i].Partition; p != nil {
+				fixed[i] = *p
+			}
+		}
+		balancer = &fixedPartitionBalancer{partitions: fixed, next: balancer}
+
+		assignment, err := assignPartitions(topic, balancer, messages, byTopic[topic])
+		if err != nil {
+			respondPartitionError(c, err)
+			return
+		}
+		assignments[topic] = assignment
+	}
+
+	switch {
+	case req.TransactionalID == "":
+		req.TransactionalID = newTransactionalID()
+	case !strings.HasPrefix(req.TransactionalID, transactionalIDPrefix):
+		req.TransactionalID = transactionalIDPrefix + req.TransactionalID
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	producer, err := initProducer(ctx, req.TransactionalID, timeout)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to init transactional producer: %v", err)})
+		return
+	}
+
+	if err := producer.addPartitions(ctx, assignments); err != nil {
+		producer.end(ctx, false) // 일부 파티션이 등록됐을 수 있으므로 정리
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to add partitions to transaction: %v", err)})
+		return
+	}
+
+	results := make([]ProduceResult, len(messages))
+	for i, m := range messages {
+		results[i] = ProduceResult{Index: i, Topic: m.Topic, Key: string(m.Key)}
+	}
+	var wg sync.WaitGroup
+	for _, topic := range topics {
+		for partition, indexes := range assignments[topic] {
+			wg.Add(1)
+			go func(topic string, partition int, indexes []int) {
+				defer wg.Done()
+				producer.produce(ctx, topic, partition, 0, compression, messages, indexes, results)
+			}(topic, partition, indexes)
+		}
+	}
+	wg.Wait()
+
+	var firstErr string
+	for _, r := range results {
+		if r.Error != "" {
+			firstErr = fmt.Sprintf("messages[%d]: %s", r.Index, r.Error)
+			break
+		}
+	}
+
+	commit := req.Action == "commit" && firstErr == ""
+	if err := producer.end(ctx, commit); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":            fmt.Sprintf("Failed to end transaction (the broker aborts it after the transaction timeout): %v", err),
+			"transactional_id": req.TransactionalID,
+			"results":          results,
+		})
+		return
+	}
+
+	status, message := "committed", "Transaction committed"
+	if !commit {
+		status, message = "aborted", "Transaction aborted"
+	}
+	body := gin.H{
+		"status":           status,
+		"transactional_id": req.TransactionalID,
+		"producer_id":      producer.producerID,
+		"producer_epoch":   producer.epoch,
+		"topics":           topics,
+		"message_count":    len(messages),
+		"results":          results,
+		"message":          message,
+	}
+	if firstErr != "" {
+		body["error"] = fmt.Sprintf("Transaction aborted because a message failed: %s", firstErr)
+		c.JSON(http.StatusInternalServerError, body)
+		return
+	}
+	c.JSON(http.StatusOK, body)
+}
+
+// idempotentSession 프로세스 전체에서 공유하는 멱등 Producer 세션
+//
+// producer id는 한 번만 발급받고 파티션별로 다음 시퀀스를 이어서 사용한다.
+// 같은 파티션으로의 전송은 시퀀스 순서를 지키도록 직렬화한다.
+type idempotentSession struct {
+	mu         sync.Mutex
+	producer   *txnProducer
+	partitions map[string]*idempotentPartition
+}
+
+// idempotentPartition 파티션별 다음 시퀀스 (producer가 바뀌면 0부터 다시 시작)
+type idempotentPartition struct {
+	mu       sync.Mutex
+	producer *txnProducer
+	sequence int32
+}
+
+var idempotent idempotentSession
+
+// partition 파티션의 시퀀스 상태 조회 (없으면 생성)
+func (s *idempotentSession) partition(topic string, partition int) *idempotentPartition {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.partitions == nil {
+		s.partitions = make(map[string]*idempotentPartition)
+	}
+	key := partitionKey(topic, partition)
+	p, ok := s.partitions[key]
+	if !ok {
+		p = &idempotentPartition{}
+		s.partitions[key] = p
+	}
+	return p
+}
+
+// current 현재 세션의 producer (없으면 새로 발급)
+func (s *idempotentSession) current(ctx context.Context) (*txnProducer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.producer != nil {
+		return s.producer, nil
+	}
+	producer, err := initProducer(ctx, "", 0)
+	if err != nil {
+		return nil, err
+	}
+	s.producer = producer
+	return producer, nil
+}
+
+// reset 시퀀스를 알 수 없게 된 세션 폐기 (다음 전송에서 새 producer id 발급)
+func (s *idempotentSession) reset(producer *txnProducer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.producer == producer {
+		s.producer = nil
+	}
+}
+
+// writeIdempotent 멱등 Producer로 동기 전송 (Writer의 토픽, Balancer, 압축 설정 사용)
+//
+// 공유 세션의 producer id와 파티션별 시퀀스로 보내므로, 네트워크 오류로 재시도해도
+// 브로커가 중복을 걸러 메시지가 한 번만 기록된다. 전송이 실패하면 시퀀스를 신뢰할 수
+// 없으므로 세션을 폐기하고, 브로커가 producer id를 잊은 경우에는 새 세션으로 한 번 다시 보낸다.
+func writeIdempotent(ctx context.Context, writer *kafka.Writer, messages []kafka.Message) []ProduceResult {
+	results := make([]ProduceResult, len(messages))
+	indexes := make([]int, len(messages))
+	for i := range messages {
+		results[i] = ProduceResult{Index: i, Key: string(messages[i].Key)}
+		messages[i].WriterData = i
+		indexes[i] = i
+	}
+	fail := func(err error) []ProduceResult {
+		for i := range results {
+			results[i].Error = err.Error()
+		}
+		return results
+	}
+
+	assignment, err := assignPartitions(writer.Topic, writer.Balancer, messages, indexes)
+	if err != nil {
+		return fail(err)
+	}
+
+	var wg sync.WaitGroup
+	for partition, indexes := range assignment {
+		wg.Add(1)
+		go func(partition int, indexes []int) {
+			defer wg.Done()
+			writeIdempotentPartition(ctx, writer, partition, messages, indexes, results)
+		}(partition, indexes)
+	}
+	wg.Wait()
+	return results
+}
+
+// writeIdempotentPartition 파티션 하나에 공유 세션의 다음 시퀀스로 전송
+func writeIdempotentPartition(ctx context.Context, writer *kafka.Writer, partition int, messages []kafka.Message, indexes []int, results []ProduceResult) {
+	state := idempotent.partition(writer.Topic, partition)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	for attempt := 1; ; attempt++ {
+		producer, err := idempotent.current(ctx)
+		if err != nil {
+			for _, i := range indexes {
+				results[i].Error = fmt.Sprintf("init producer id: %v", err)
+			}
+			return
+		}
+		if state.producer != producer {
+			state.producer, state.sequence = producer, 0
+		}
+
+		err = producer.produce(ctx, writer.Topic, partition, state.sequence, writer.Compression, messages, indexes, results)
+		if err == nil {
+			state.sequence = nextSequence(state.sequence, len(indexes))
+			return
+		}
+
+		idempotent.reset(producer)
+		if attempt > 1 || !(errors.Is(err, kafka.UnknownProducerId) || errors.Is(err, kafka.OutOfOrderSequenceNumber)) {
+			return
+		}
+	}
+}
+
+// nextSequence 레코드 n개를 보낸 뒤의 다음 시퀀스 (int32 최대값을 넘으면 0부터 다시 시작)
+func nextSequence(sequence int32, n int) int32 {
+	next := int64(sequence) + int64(n)
+	if next > math.MaxInt32 {
+		next -= math.MaxInt32 + 1
+	}
+	return int32(next)
+}
+
+// assignPartitions Balancer로 메시지별 파티션 배정 (파티션 → 메시지 인덱스, 요청 순서 유지)
+func assignPartitions(topic string, balancer kafka.Balancer, messages []kafka.Message, indexes []int) (map[int][]int, error) {
+	partitions, err := readPartitions(topic)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, len(partitions))
+	for i, p := range partitions {
+		ids[i] = p.ID
+	}
+	sort.Ints(ids)
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("topic %s has no partitions", topic)
+	}
+
+	assignment := make(map[int][]int)
+	for _, i := range indexes {
+		partition := balancer.Balance(messages[i], ids...)
+		if !containsPartition(ids, partition) {
+			return nil, &partitionNotFoundError{topic: topic, partition: partition, count: len(ids)}
+		}
+		assignment[partition] = append(assignment[partition], i)
+	}
+	return assignment, nil
+}
+
+// txnProducer 멱등/트랜잭션 Producer 세션 (producer id와 epoch)
+type txnProducer struct {
+	client          *kafka.Client
+	transactionalID string
+	producerID      int64
+	epoch           int16
+}
+
+// initProducer producer id 발급 (transactionalID가 있으면 트랜잭션 코디네이터에서 발급하고 이전 세션을 fencing)
+func initProducer(ctx context.Context, transactionalID string, timeout time.Duration) (*txnProducer, error) {
+	p := &txnProducer{client: newKafkaClient(), transactionalID: transactionalID}
+
+	// 트랜잭션 ID가 없는 멱등 Producer는 코디네이터를 찾지 않고 아무 브로커에 요청
+	if transactionalID == "" {
+		msg, err := kafkaext.RoundTrip(ctx, kafkaBrokers, 0, &initproducerid.Request{TransactionTimeoutMs: -1})
+		if err != nil {
+			return nil, err
+		}
+		resp := msg.(*initproducerid.Response)
+		if resp.ErrorCode != 0 {
+			return nil, kafka.Error(resp.ErrorCode)
+		}
+		p.producerID, p.epoch = resp.ProducerID, resp.ProducerEpoch
+		return p, nil
+	}
+
+	err := retryTxn(ctx, func() error {
+		resp, err := p.client.InitProducerID(ctx, &kafka.InitProducerIDRequest{
+			TransactionalID:      transactionalID,
+			TransactionTimeoutMs: int(timeout.Milliseconds()),
+		})
+		if err != nil {
+			return err
+		}
+		if resp.Error != nil {
+			return resp.Error
+		}
+		p.producerID, p.epoch = int64(resp.Producer.ProducerID), int16(resp.Producer.ProducerEpoch)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// addPartitions 전송할 파티션을 트랜잭션에 등록
+func (p *txnProducer) addPartitions(ctx context.Context, assignments map[string]map[int][]int) error {
+	topics := make(map[string][]kafka.AddPartitionToTxn, len(assignments))
+	for topic, assignment := range assignments {
+		for partition := range assignment {
+			topics[topic] = append(topics[topic], kafka.AddPartitionToTxn{Partition: partition})
+		}
+	}
+
+	return retryTxn(ctx, func() error {
+		resp, err := p.client.AddPartitionsToTxn(ctx, &kafka.AddPartitionsToTxnRequest{
+			TransactionalID: p.transactionalID,
+			ProducerID:      int(p.producerID),
+			ProducerEpoch:   int(p.epoch),
+			Topics:          topics,
+		})
+		if err != nil {
+			return err
+		}
+		for topic, partitions := range resp.Topics {
+			for _, partition := range partitions {
+				if partition.Error != nil {
+					return fmt.Errorf("%s[%d]: %w", topic, partition.Partition, partition.Error)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// end 트랜잭션 커밋 또는 중단
+func (p *txnProducer) end(ctx context.Context, commit bool) error {
+	return retryTxn(ctx, func() error {
+		resp, err := p.client.EndTxn(ctx, &kafka.EndTxnRequest{
+			TransactionalID: p.transactionalID,
+			ProducerID:      int(p.producerID),
+			ProducerEpoch:   int(p.epoch),
+			Committed:       commit,
+		})
+		if err != nil {
+			return err
+		}
+		return resp.Error
+	})
+}
+
+// produce 파티션 하나에 메시지를 sequence부터 시작하는 레코드 배치 하나로 전송하고 results에 결과 기록
+//
+// 트랜잭션은 세션마다 파티션당 배치를 하나만 보내므로 시퀀스 0을 사용한다.
+// 실패하면 마지막 오류를 반환한다 (이미 기록된 배치의 재전송은 성공으로 처리).
+func (p *txnProducer) produce(ctx context.Context, topic string, partition int, sequence int32, compression kafka.Compression, messages []kafka.Message, indexes []int, results []ProduceResult) error {
+	now := time.Now()
+	records := make([]protocol.Record, len(indexes))
+	for k, i := range indexes {
+		m := messages[i]
+		if m.Time.IsZero() {
+			m.Time = now
+		}
+		records[k] = protocol.Record{
+			Time:    m.Time,
+			Key:     protocol.NewBytes(m.Key),
+			Value:   protocol.NewBytes(m.Value),
+			Headers: m.Headers,
+		}
+	}
+
+	batch := kafkaext.ProducerBatch{
+		ProducerID:    p.producerID,
+		ProducerEpoch: p.epoch,
+		BaseSequence:  sequence,
+		Transactional: p.transactionalID != "",
+		Compression:   compression,
+		Records:       records,
+	}
+	data, err := batch.Encode()
+
+	var resp *kafka.ProduceResponse
+	for attempt := 1; err == nil; attempt++ {
+		resp, err = p.client.RawProduce(ctx, &kafka.RawProduceRequest{
+			Topic:           topic,
+			Partition:       partition,
+			RequiredAcks:    kafka.RequireAll,
+			TransactionalID: p.transactionalID,
+			RawRecords:      protocol.RawRecordSet{Reader: bytes.NewReader(data)},
+		})
+		if err == nil {
+			err = resp.Error
+		}
+		if err == nil || attempt == produceMaxAttempts || !retriableProduceError(err) {
+			break
+		}
+		if err = sleepContext(ctx, time.Duration(attempt)*100*time.Millisecond); err != nil {
+			break
+		}
+	}
+
+	// 재시도한 배치가 이미 기록돼 있으면 오프셋 없이 성공으로 처리
+	duplicate := errors.Is(err, kafka.DuplicateSequenceNumber)
+	for k, i := range indexes {
+		results[i].Error = ""
+		switch {
+		case duplicate:
+			partition := partition
+			results[i].Partition = &partition
+		case err != nil:
+			results[i].Error = err.Error()
+		default:
+			partition, offset := partition, resp.BaseOffset+int64(k)
+			timestamp, timestampType := records[k].Time, "CreateTime"
+			if !resp.LogAppendTime.IsZero() {
+				timestamp, timestampType = resp.LogAppendTime.UTC(), "LogAppendTime"
+			}
+			results[i].Partition = &partition
+			results[i].Offset = &offset
+			results[i].Timestamp = &timestamp
+			results[i].TimestampType = timestampType
+		}
+	}
+	if duplicate {
+		return nil
+	}
+	return err
+}
+
+// retriableProduceError 같은 배치를 다시 보내도 되는 오류 (연결 오류나 일시적인 브로커 오류)
+func retriableProduceError(err error) bool {
+	var kafkaErr kafka.Error
+	if errors.As(err, &kafkaErr) {
+		return kafkaErr.Temporary()
+	}
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// retryTxn 코디네이터 이동, 이전 트랜잭션 마무리 중 등 일시적인 오류면 재시도
+func retryTxn(ctx context.Context, fn func() error) error {
+	var err error
+	for attempt := 1; attempt <= txnMaxAttempts; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		var kafkaErr kafka.Error
+		if !errors.As(err, &kafkaErr) || !(kafkaErr.Temporary() || kafkaErr == kafka.ConcurrentTransactions) {
+			return err
+		}
+		if sleepErr := sleepContext(ctx, time.Duration(attempt)*100*time.Millisecond); sleepErr != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// sleepContext ctx가 끝나기 전까지 d만큼 대기
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// newTransactionalID 요청별 트랜잭션 ID 생성
+func newTransactionalID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("kafka-monitor-%d", time.Now().UnixNano())
+	}
+	return "kafka-monitor-" + hex.EncodeToString(b)
+}
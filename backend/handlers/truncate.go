@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"backend/kafkaext/deleterecords"
+
+	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
+)
+
+// TruncateTopicRequest 레코드 삭제(파티션 앞부분 잘라내기) 요청
+type TruncateTopicRequest struct {
+	// Offsets 파티션별 기준 오프셋, 이 오프셋 이전 레코드 삭제 (-1이면 high watermark까지 전부)
+	Offsets map[int]int64 `json:"offsets"`
+	// Timestamp 이 시각 이전에 기록된 레코드 삭제
+	Timestamp *time.Time `json:"timestamp"`
+	// Partitions timestamp 사용 시 대상 파티션 (비어 있으면 전체)
+	Partitions []int `json:"partitions"`
+}
+
+// TruncateResult 파티션별 레코드 삭제 결과
+type TruncateResult struct {
+	Partition    int     `json:"partition"`
+	BeforeOffset int64   `json:"before_offset"`
+	LowWatermark int64   `json:"low_watermark"`
+	Deleted      int64   `json:"deleted"`
+	Offsets      Offsets `json:"offsets"`
+	Error        string  `json:"error,omitempty"`
+}
+
+// TruncateTopic 지정한 오프셋 또는 시각 이전의 레코드 삭제 (DeleteRecords)
+func TruncateTopic(c *gin.Context) {
+	topicName := c.Param("name")
+
+	// 내부 토픽과 보호 토픽은 레코드 삭제도 토픽 삭제와 같이 금지
+	if reason := protectedTopicReason(topicName); reason != "" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": fmt.Sprintf("Topic %s is protected (%s)", topicName, reason),
+		})
+		return
+	}
+
+	var req TruncateTopicRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if (len(req.Offsets) == 0) == (req.Timestamp == nil) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "exactly one of offsets or timestamp is required",
+		})
+		return
+	}
+
+	partitions, err := readPartitions(topicName)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to read partitions: %v", err),
+		})
+		return
+	}
+
+	if len(partitions) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Topic not found",
+		})
+		return
+	}
+
+	// 삭제 전 오프셋 (getPartitionOffsets와 동일한 계산)
+	before := make(map[int]Offsets, len(partitions))
+	for _, p := range partitions {
+		first, last := getPartitionOffsets(topicName, p.ID)
+		before[p.ID] = Offsets{First: first, Last: last}
+	}
+
+	targets := req.Offsets
+	if req.Timestamp != nil {
+		targets, err = offsetsForTimestamp(topicName, before, req.Partitions, *req.Timestamp)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": fmt.Sprintf("Failed to look up offsets for timestamp: %v", err),
+			})
+			return
+		}
+	}
+
+	for partition, offset := range targets {
+		current, ok := before[partition]
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("partition %d does not exist", partition),
+			})
+			return
+		}
+		if offset == -1 {
+			targets[partition] = current.Last
+			continue
+		}
+		if offset < 0 || offset > current.Last {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("offset %d for partition %d is out of range [0, %d]", offset, partition, current.Last),
+			})
+			return
+		}
+	}
+
+	resp, err := deleteRecords(topicName, targets)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fmt.Sprintf("Failed to delete records: %v", err),
+		})
+		return
+	}
+
+	results := make([]TruncateResult, 0, len(targets))
+	failed := 0
+	for _, t := range resp.Topics {
+		for _, p := range t.Partitions {
+			partition := int(p.PartitionIndex)
+			first, last := getPartitionOffsets(topicName, partition)
+
+			result := TruncateResult{
+				Partition:    partition,
+				BeforeOffset: targets[partition],
+				LowWatermark: p.LowWatermark,
+				Offsets:      Offsets{First: first, Last: last},
+			}
+			if p.ErrorCode != 0 {
+				result.Error = kafka.Error(p.ErrorCode).Error()
+				failed++
+			} else if old := before[partition].First; old >= 0 && p.LowWatermark > old {
+				result.Deleted = p.LowWatermark - old
+			}
+			results = append(results, result)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Partition < results[j].Partition
+	})
+
+	// 모든 파티션이 실패하면 500, 일부만 실패하면 207 partial
+	status, code, message := "success", http.StatusOK, "Records deleted successfully"
+	switch {
+	case failed > 0 && failed == len(results):
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":      fmt.Sprintf("Failed to delete records: %s", results[0].Error),
+			"topic":      topicName,
+			"partitions": results,
+			"failed":     failed,
+		})
+		return
+	case failed > 0:
+		status, code = "partial", http.StatusMultiStatus
+		message = fmt.Sprintf("Records deleted from %d of %d partitions", len(results)-failed, len(results))
+	}
+
+	c.JSON(code, gin.H{
+		"status":     status,
+		"topic":      topicName,
+		"partitions": results,
+		"failed":     failed,
+		"message":    message,
+	})
+}
+
+// deleteRecords 파티션별 기준 오프셋 이전 레코드 삭제 요청 전송
+func deleteRecords(topic string, offsets map[int]int64) (*deleterecords.Response, error) {
+	partitions := make([]deleterecords.RequestPartition, 0, len(offsets))
+	for partition, offset := range offsets {
+		partitions = append(partitions, deleterecords.RequestPartition{
+			PartitionIndex: int32(partition),
+			Offset:         offset,
+		})
+	}
+
+	req := &deleterecords.Request{
+		Topics: []deleterecords.RequestTopic{
+			{Name: topic, Partitions: partitions},
+		},
+		TimeoutMs: 30000,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	msg, err := kafka.DefaultTransport.RoundTrip(ctx, kafka.TCP(kafkaBrokers), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg.(*deleterecords.Response), nil
+}
+
+// offsetsForTimestamp 시각 기준으로 파티션별 삭제 오프셋 계산 (이후 메시지가 없으면 전체 삭제)
+func offsetsForTimestamp(topic string, current map[int]Offsets, partitions []int, at time.Time) (map[int]int64, error) {
+	if len(partitions) == 0 {
+		for id := range current {
+			partitions = append(partitions, id)
+		}
+	}
+
+	requests := make([]kafka.OffsetRequest, 0, len(partitions))
+	for _, id := range partitions {
+		if _, ok := current[id]; !ok {
+			return nil, fmt.Errorf("partition %d does not exist", id)
+		}
+		requests = append(requests, kafka.TimeOffsetOf(id, at))
+	}
+
+	client := newKafkaClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := client.ListOffsets(ctx, &kafka.ListOffsetsRequest{
+		Topics: map[string][]kafka.OffsetRequest{topic: requests},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	offsets := make(map[int]int64, len(partitions))
+	for _, p := range resp.Topics[topic] {
+		if p.Error != nil {
+			return nil, fmt.Errorf("partition %d: %w", p.Partition, p.Error)
+		}
+
+		offset := int64(-1)
+		for o := range p.Offsets {
+			offset = o
+		}
+		if offset < 0 {
+			offset = current[p.Partition].Last
+		}
+		offsets[p.Partition] = offset
+	}
+
+	if len(offsets) != len(partitions) {
+		return nil, errors.New("incomplete ListOffsets response")
+	}
+
+	return offsets, nil
+}
@@ -0,0 +1,150 @@
+// Package deleterecords kafka-go v0.4.47에 없는 DeleteRecords API 정의
+//
+// kafka-go의 protocol 패키지에 등록해 kafka.Transport로 그대로 전송할 수 있으며,
+// 파티션 리더별로 요청을 나누고 응답을 다시 합친다.
+package deleterecords
+
+import (
+	"sort"
+
+	"github.com/segmentio/kafka-go/protocol"
+)
+
+func init() {
+	protocol.Register(&Request{}, &Response{})
+}
+
+// Detailed API definition: https://kafka.apache.org/protocol#The_Messages_DeleteRecords
+type Request struct {
+	Topics    []RequestTopic `kafka:"min=v0,max=v1"`
+	TimeoutMs int32          `kafka:"min=v0,max=v1"`
+}
+
+type RequestTopic struct {
+	Name       string             `kafka:"min=v0,max=v1"`
+	Partitions []RequestPartition `kafka:"min=v0,max=v1"`
+}
+
+type RequestPartition struct {
+	PartitionIndex int32 `kafka:"min=v0,max=v1"`
+	// Offset 이 오프셋 이전의 레코드를 삭제 (-1이면 high watermark)
+	Offset int64 `kafka:"min=v0,max=v1"`
+}
+
+func (r *Request) ApiKey() protocol.ApiKey { return protocol.DeleteRecords }
+
+// Broker Split으로 나뉜 요청(파티션 1개)을 해당 파티션 리더로 보냄
+func (r *Request) Broker(cluster protocol.Cluster) (protocol.Broker, error) {
+	topic := r.Topics[0].Name
+	partition := r.Topics[0].Partitions[0].PartitionIndex
+
+	for _, p := range cluster.Topics[topic].Partitions {
+		if p.ID == partition {
+			return cluster.Brokers[p.Leader], nil
+		}
+	}
+
+	return protocol.Broker{ID: -1}, nil
+}
+
+// Split DeleteRecords는 파티션 리더에게 보내야 하므로 파티션마다 요청을 나눔
+func (r *Request) Split(cluster protocol.Cluster) ([]protocol.Message, protocol.Merger, error) {
+	requests := make([]Request, 0, len(r.Topics))
+
+	for _, t := range r.Topics {
+		for _, p := range t.Partitions {
+			requests = append(requests, Request{
+				Topics: []RequestTopic{{
+					Name:       t.Name,
+					Partitions: []RequestPartition{p},
+				}},
+				TimeoutMs: r.TimeoutMs,
+			})
+		}
+	}
+
+	messages := make([]protocol.Message, len(requests))
+	for i := range requests {
+		messages[i] = &requests[i]
+	}
+
+	return messages, new(Response), nil
+}
+
+type Response struct {
+	ThrottleTimeMs int32           `kafka:"min=v0,max=v1"`
+	Topics         []ResponseTopic `kafka:"min=v0,max=v1"`
+}
+
+type ResponseTopic struct {
+	Name       string              `kafka:"min=v0,max=v1"`
+	Partitions []ResponsePartition `kafka:"min=v0,max=v1"`
+}
+
+type ResponsePartition struct {
+	PartitionIndex int32 `kafka:"min=v0,max=v1"`
+	LowWatermark   int64 `kafka:"min=v0,max=v1"`
+	ErrorCode      int16 `kafka:"min=v0,max=v1"`
+}
+
+func (r *Response) ApiKey() protocol.ApiKey { return protocol.DeleteRecords }
+
+// Merge 파티션별 응답을 하나로 합침 (전송 실패한 파티션은 ErrorCode -1)
+func (r *Response) Merge(requests []protocol.Message, results []interface{}) (protocol.Message, error) {
+	topics := make(map[string][]ResponsePartition)
+	errors := 0
+
+	for i, res := range results {
+		m, err := protocol.Result(res)
+		if err != nil {
+			for _, t := range requests[i].(*Request).Topics {
+				for _, p := range t.Partitions {
+					topics[t.Name] = append(topics[t.Name], ResponsePartition{
+						PartitionIndex: p.PartitionIndex,
+						LowWatermark:   -1,
+						ErrorCode:      -1,
+					})
+				}
+			}
+			errors++
+			continue
+		}
+
+		response := m.(*Response)
+		if r.ThrottleTimeMs < response.ThrottleTimeMs {
+			r.ThrottleTimeMs = response.ThrottleTimeMs
+		}
+
+		for _, t := range response.Topics {
+			topics[t.Name] = append(topics[t.Name], t.Partitions...)
+		}
+	}
+
+	if errors > 0 && errors == len(results) {
+		_, err := protocol.Result(results[0])
+		return nil, err
+	}
+
+	r.Topics = make([]ResponseTopic, 0, len(topics))
+	for name, partitions := range topics {
+		sort.Slice(partitions, func(i, j int) bool {
+			return partitions[i].PartitionIndex < partitions[j].PartitionIndex
+		})
+		r.Topics = append(r.Topics, ResponseTopic{
+			Name:       name,
+			Partitions: partitions,
+		})
+	}
+
+	sort.Slice(r.Topics, func(i, j int) bool {
+		return r.Topics[i].Name < r.Topics[j].Name
+	})
+
+	return r, nil
+}
+
+var (
+	_ protocol.BrokerMessage = (*Request)(nil)
+	_ protocol.Splitter      = (*Request)(nil)
+	_ protocol.Merger        = (*Response)(nil)
+)
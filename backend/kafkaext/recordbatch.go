@@ -0,0 +1,66 @@
+package kafkaext
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+
+	"github.com/segmentio/kafka-go/compress"
+	"github.com/segmentio/kafka-go/protocol"
+)
+
+// 레코드 배치 v2 헤더의 필드 위치 (크기 접두사 4바이트 이후 기준)
+const (
+	batchSizePrefix      = 4
+	batchCRCOffset       = 17
+	batchAttributesStart = 21
+	batchProducerID      = 43
+	batchProducerEpoch   = 51
+	batchBaseSequence    = 53
+	batchHeaderSize      = 61
+)
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// ProducerBatch 멱등/트랜잭션 Producer가 보내는 레코드 배치
+//
+// kafka-go는 레코드 배치 헤더의 producer id, epoch, base sequence를 항상 -1로 쓰기
+// 때문에 같은 방식으로 인코딩한 뒤 해당 필드를 채우고 CRC를 다시 계산한다.
+type ProducerBatch struct {
+	ProducerID    int64
+	ProducerEpoch int16
+	BaseSequence  int32
+	Transactional bool
+	Compression   compress.Compression
+	Records       []protocol.Record
+}
+
+// Encode RawProduce 요청의 레코드 셋으로 보낼 바이트 (재시도 시 같은 바이트를 다시 보냄)
+func (b *ProducerBatch) Encode() ([]byte, error) {
+	attributes := protocol.Attributes(b.Compression)
+	if b.Transactional {
+		attributes |= protocol.Transactional
+	}
+
+	rs := protocol.RecordSet{
+		Version:    2,
+		Attributes: attributes,
+		Records:    protocol.NewRecordReader(b.Records...),
+	}
+	var buf bytes.Buffer
+	if _, err := rs.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+
+	data := buf.Bytes()
+	if len(data) < batchSizePrefix+batchHeaderSize {
+		return nil, fmt.Errorf("record batch too short (%d bytes)", len(data))
+	}
+	batch := data[batchSizePrefix:]
+	binary.BigEndian.PutUint64(batch[batchProducerID:], uint64(b.ProducerID))
+	binary.BigEndian.PutUint16(batch[batchProducerEpoch:], uint16(b.ProducerEpoch))
+	binary.BigEndian.PutUint32(batch[batchBaseSequence:], uint32(b.BaseSequence))
+	binary.BigEndian.PutUint32(batch[batchCRCOffset:], crc32.Checksum(batch[batchAttributesStart:], castagnoli))
+	return data, nil
+}
@@ -0,0 +1,32 @@
+// Package kafkaext kafka-go가 직접 지원하지 않는 요청을 보내기 위한 확장
+package kafkaext
+
+import (
+	"context"
+	"net"
+
+	"github.com/segmentio/kafka-go/protocol"
+)
+
+// clientID 직접 전송하는 요청에 사용할 클라이언트 ID
+const clientID = "kafka-monitor"
+
+// RoundTrip 지정한 브로커에 연결해 요청 하나를 보내고 응답을 받음
+//
+// kafka.Transport는 메시지 타입별 라우팅 규칙을 강제하기 때문에 (예: 브로커 리소스
+// 이름을 숫자로 해석) 클러스터 기본값처럼 규칙에 맞지 않는 요청은 이 함수로 보낸다.
+// apiVersion은 호출하는 쪽에서 브로커가 지원하는 버전으로 지정해야 한다.
+func RoundTrip(ctx context.Context, addr string, apiVersion int16, req protocol.Message) (protocol.Message, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	return protocol.RoundTrip(conn, apiVersion, 1, clientID, req)
+}
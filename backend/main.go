@@ -3,7 +3,10 @@ package main
 import (
 	"log"
 	"os"
+	"strings"
+	"time"
 
+	"backend/auth"
 	"backend/handlers"
 
 	"github.com/gin-contrib/cors"
@@ -17,41 +20,184 @@ func main() {
 		kafkaBrokers = "localhost:9092"
 	}
 
-	// Gin 라우터 초기화
-	router := gin.Default()
+	// 토픽 정책 로드 (선택사항)
+	if policyFile := os.Getenv("TOPIC_POLICY_FILE"); policyFile != "" {
+		if err := handlers.LoadTopicPolicy(policyFile); err != nil {
+			log.Fatalf("Failed to load topic policy: %v", err)
+		}
+		log.Printf("Loaded topic policy from %s", policyFile)
+	}
+
+	// 인증/권한 설정 로드 (없으면 인증 비활성화)
+	if authFile := os.Getenv("AUTH_CONFIG"); authFile != "" {
+		if err := auth.Load(authFile); err != nil {
+			log.Fatalf("Failed to load auth config: %v", err)
+		}
+		log.Printf("Loaded auth config from %s", authFile)
+	} else {
+		log.Printf("WARNING: AUTH_CONFIG is not set, API authentication is disabled")
+	}
+
+	// 핸들러 초기화
+	handlers.InitKafkaClient(kafkaBrokers)
+	if err := handlers.InitKafkaClusters(os.Getenv("KAFKA_CLUSTERS")); err != nil {
+		log.Fatalf("Invalid KAFKA_CLUSTERS: %v", err)
+	}
+
+	// 감사 기록 (추가 전용 파일, AUDIT_TOPIC 지정 시 Kafka 토픽에도 기록)
+	auditFile := os.Getenv("AUDIT_LOG_FILE")
+	if auditFile == "" {
+		auditFile = "audit.log"
+	}
+	if err := handlers.InitAuditLog(auditFile, os.Getenv("AUDIT_TOPIC")); err != nil {
+		log.Fatalf("Failed to open audit log: %v", err)
+	}
+
+	// GitOps CLI 모드 (main gitops plan|apply ...)
+	if len(os.Args) > 1 && os.Args[1] == "gitops" {
+		code := runGitOps(os.Args[2:])
+		handlers.CloseAuditLog()
+		os.Exit(code)
+	}
+
+	// DLQ 메시지 처리 이력 색인 (감사 기록 파일에서 한 번 읽음)
+	if err := handlers.LoadDLQActions(); err != nil {
+		log.Fatalf("Failed to load DLQ actions from audit log: %v", err)
+	}
+
+	// 지연 카나리 (CANARY_TOPIC 지정 시 프로브 토픽으로 end-to-end 지연 측정)
+	if canaryTopic := os.Getenv("CANARY_TOPIC"); canaryTopic != "" {
+		cfg := handlers.CanaryConfig{Topic: canaryTopic}
+		for _, env := range []struct {
+			name  string
+			value *time.Duration
+		}{
+			{"CANARY_INTERVAL", &cfg.Interval},
+			{"CANARY_TIMEOUT", &cfg.Timeout},
+			{"CANARY_WINDOW", &cfg.Window},
+			{"CANARY_WARN_LATENCY", &cfg.WarnLatency},
+			{"CANARY_CRITICAL_LATENCY", &cfg.CriticalLatency},
+		} {
+			if v := os.Getenv(env.name); v != "" {
+				d, err := time.ParseDuration(v)
+				if err != nil {
+					log.Fatalf("Invalid %s: %v", env.name, err)
+				}
+				*env.value = d
+			}
+		}
+		if err := handlers.StartCanary(cfg); err != nil {
+			log.Fatalf("Failed to start latency canary: %v", err)
+		}
+		log.Printf("Started latency canary on topic %s", canaryTopic)
+	}
+
+	// Gin 라우터 초기화 (요청 로그에서 access_token 쿼리 값은 가림)
+	router := gin.New()
+	router.Use(gin.LoggerWithConfig(gin.LoggerConfig{Output: auth.RedactingWriter(gin.DefaultWriter)}), gin.Recovery())
 
 	// CORS 설정
 	config := cors.DefaultConfig()
-	config.AllowAllOrigins = true
+	if origins := os.Getenv("CORS_ALLOWED_ORIGINS"); origins != "" {
+		config.AllowOrigins = strings.Split(origins, ",")
+	} else {
+		config.AllowAllOrigins = true
+	}
 	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization"}
-	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
+	config.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
 	router.Use(cors.New(config))
 
-	// 핸들러 초기화
-	handlers.InitKafkaClient(kafkaBrokers)
-
 	// API 라우트 설정
 	api := router.Group("/api")
+	api.Use(auth.Authenticate("/api/consume/ws"))
 	{
+		viewer := auth.Require(auth.RoleViewer)
+		operator := auth.Require(auth.RoleOperator)
+		admin := auth.Require(auth.RoleAdmin)
+
+		// 현재 사용자 조회
+		api.GET("/me", auth.WhoAmI)
+
 		// Producer API
-		api.POST("/produce", handlers.ProduceMessage)
-		api.POST("/produce/batch", handlers.ProduceBatchMessages)
+		api.POST("/produce", handlers.Audit("message.produce", auth.TopicFromBody("topic")), auth.Require(auth.RoleProducer, auth.TopicFromBody("topic")), handlers.ProduceMessage)
+		api.POST("/produce/batch", handlers.Audit("message.produce_batch", auth.TopicFromBody("topic")), auth.Require(auth.RoleProducer, auth.TopicFromBody("topic")), handlers.ProduceBatchMessages)
+		api.POST("/produce/upload", handlers.Audit("message.produce_upload", auth.TopicFromQuery("topic")), auth.Require(auth.RoleProducer, auth.TopicFromQuery("topic")), handlers.UploadProduceMessages)
+		api.POST("/produce/transaction", handlers.Audit("message.produce_transaction"), auth.RequireEach(auth.RoleProducer, auth.TopicsFromBodyArray("messages", "topic")), handlers.ProduceTransaction)
+
+		// 부하 생성 API
+		api.GET("/loadgen", viewer, handlers.ListLoadGenJobs)
+		api.POST("/loadgen", handlers.Audit("loadgen.start", auth.TopicFromBody("topic")), auth.Require(auth.RoleProducer, auth.TopicFromBody("topic")), handlers.StartLoadGen)
+		api.GET("/loadgen/:id", auth.Require(auth.RoleViewer, handlers.LoadGenJobTopic("id")), handlers.GetLoadGenJob)
+		api.DELETE("/loadgen/:id", handlers.Audit("loadgen.stop", handlers.LoadGenJobTopic("id")), auth.Require(auth.RoleProducer, handlers.LoadGenJobTopic("id")), handlers.StopLoadGenJob)
+
+		// 토픽 복사/재처리 API
+		api.GET("/copy", viewer, handlers.ListCopyJobs)
+		api.POST("/copy", handlers.Audit("topic.copy", auth.TopicFromBody("targetTopic")), auth.Require(auth.RoleViewer, auth.TopicFromBody("sourceTopic")), auth.Require(auth.RoleProducer, auth.TopicFromBody("targetTopic")), handlers.StartCopyJob)
+		api.GET("/copy/:id", auth.Require(auth.RoleViewer, handlers.CopyJobSourceTopic("id")), auth.Require(auth.RoleViewer, handlers.CopyJobTopic("id")), handlers.GetCopyJob)
+		api.DELETE("/copy/:id", handlers.Audit("topic.copy.stop", handlers.CopyJobTopic("id")), auth.Require(auth.RoleProducer, handlers.CopyJobTopic("id")), handlers.StopCopyJob)
+
+		// DLQ API
+		api.GET("/dlq", viewer, handlers.ListDLQTopics)
+		api.GET("/dlq/:topic", auth.Require(auth.RoleViewer, auth.TopicFromParam("topic")), handlers.BrowseDLQ)
+		api.GET("/dlq/:topic/messages/:partition/:offset", auth.Require(auth.RoleViewer, auth.TopicFromParam("topic")), handlers.GetDLQMessage)
+		api.POST("/dlq/:topic/redrive", handlers.Audit("dlq.redrive", auth.TopicFromParam("topic")), auth.Require(auth.RoleProducer, auth.TopicFromParam("topic")), handlers.RedriveDLQMessages)
+		api.POST("/dlq/:topic/skip", handlers.Audit("dlq.skip", auth.TopicFromParam("topic")), auth.Require(auth.RoleProducer, auth.TopicFromParam("topic")), handlers.SkipDLQMessages)
 
 		// Consumer API
-		api.GET("/consume", handlers.ConsumeMessages)
-		api.GET("/consume/ws", handlers.ConsumeMessagesWebSocket)
+		api.GET("/consume", auth.Require(auth.RoleViewer, auth.TopicFromQuery("topic")), handlers.ConsumeMessages)
+		api.GET("/consume/ws", auth.Require(auth.RoleViewer, auth.TopicFromQuery("topic")), handlers.ConsumeMessagesWebSocket)
 
 		// Topic 관리 API
-		api.GET("/topics", handlers.ListTopics)
-		api.POST("/topics", handlers.CreateTopic)
-		api.GET("/topics/:name", handlers.GetTopicDetails)
-		api.DELETE("/topics/:name", handlers.DeleteTopic)
+		api.GET("/topics", viewer, handlers.ListTopics)
+		api.POST("/topics", handlers.Audit("topic.create", auth.TopicFromBody("name")), auth.Require(auth.RoleOperator, auth.TopicFromBody("name")), handlers.CreateTopic)
+		api.GET("/topics/:name", auth.Require(auth.RoleViewer, auth.TopicFromParam("name")), handlers.GetTopicDetails)
+		api.DELETE("/topics/:name", handlers.Audit("topic.delete", auth.TopicFromParam("name")), auth.Require(auth.RoleAdmin, auth.TopicFromParam("name")), handlers.DeleteTopic)
+		api.GET("/topics/:name/config", auth.Require(auth.RoleViewer, auth.TopicFromParam("name")), handlers.GetTopicConfig)
+		api.PATCH("/topics/:name/config", handlers.Audit("topic.config.alter", auth.TopicFromParam("name")), auth.Require(auth.RoleOperator, auth.TopicFromParam("name")), handlers.AlterTopicConfig)
+		api.POST("/topics/:name/partitions", handlers.Audit("topic.partitions.increase", auth.TopicFromParam("name")), auth.Require(auth.RoleOperator, auth.TopicFromParam("name")), handlers.IncreasePartitions)
+		api.GET("/topics/:name/backup", auth.Require(auth.RoleViewer, auth.TopicFromParam("name")), handlers.BackupTopic)
+		api.POST("/topics/:name/truncate", handlers.Audit("topic.truncate", auth.TopicFromParam("name")), auth.Require(auth.RoleOperator, auth.TopicFromParam("name")), handlers.TruncateTopic)
+
+		// ACL 관리 API
+		api.GET("/acls", viewer, handlers.ListACLs)
+		api.POST("/acls", handlers.Audit("acl.create", handlers.ClusterTarget()), admin, handlers.CreateACLs)
+		api.DELETE("/acls", handlers.Audit("acl.delete", handlers.ClusterTarget()), admin, handlers.DeleteACLs)
+		api.GET("/acls/check", viewer, handlers.CheckTopicAccess)
+
+		// Client 쿼터 API
+		api.GET("/quotas", viewer, handlers.GetClientQuotas)
+		api.PUT("/quotas", handlers.Audit("quota.alter", handlers.ClusterTarget()), admin, handlers.AlterClientQuota)
+
+		// Partition 재배치 API
+		api.POST("/reassignments/plan", operator, handlers.PlanReassignment)
+		api.POST("/reassignments", handlers.Audit("reassignment.execute", handlers.ClusterTarget()), admin, handlers.ExecuteReassignment)
+		api.GET("/reassignments", viewer, handlers.GetReassignmentProgress)
+		api.DELETE("/reassignments", handlers.Audit("reassignment.cancel", handlers.ClusterTarget()), admin, handlers.CancelReassignment)
+
+		// Leader 선출 API
+		api.GET("/leaders/skewed", viewer, handlers.GetSkewedLeaders)
+		api.POST("/leaders/elect", handlers.Audit("leader.elect", handlers.ClusterTarget()), admin, handlers.ElectLeaders)
+
+		// 감사 기록 API
+		api.GET("/audit", admin, handlers.GetAuditLog)
+
+		// 토픽 정책 API
+		api.GET("/policies/topics", viewer, handlers.GetTopicPolicy)
+
+		// GitOps API (원하는 상태 파일 기반 계획/적용)
+		api.POST("/gitops/plan", operator, handlers.PlanGitOps)
+		api.POST("/gitops/apply", handlers.Audit("gitops.apply", handlers.ClusterTarget()), admin, handlers.ApplyGitOps)
 
 		// Metrics API
-		api.GET("/metrics/consumer-groups", handlers.GetConsumerGroups)
-		api.GET("/metrics/lag", handlers.GetConsumerLag)
-		api.GET("/brokers", handlers.GetBrokers)
-		api.GET("/metrics/cluster", handlers.GetClusterMetrics)
+		api.GET("/metrics/consumer-groups", viewer, handlers.GetConsumerGroups)
+		api.GET("/metrics/lag", viewer, handlers.GetConsumerLag)
+		api.GET("/metrics/canary", viewer, handlers.GetCanaryMetrics)
+		api.GET("/cluster", viewer, handlers.GetClusterInfo)
+		api.GET("/brokers", viewer, handlers.GetBrokers)
+		api.GET("/brokers/config/diff", viewer, handlers.GetBrokerConfigDrift)
+		api.GET("/brokers/:id/config", viewer, handlers.GetBrokerConfig)
+		api.PATCH("/brokers/:id/config", handlers.Audit("broker.config.alter", handlers.BrokerTarget("id")), admin, handlers.AlterBrokerConfig)
+		api.GET("/metrics/cluster", viewer, handlers.GetClusterMetrics)
 	}
 
 	// 헬스 체크 엔드포인트
@@ -94,6 +94,12 @@ const MessageLog = ({ messages, onClear }) => {
                         <span className="ml-2 text-gray-900 font-mono text-xs">{msg.key}</span>
                       </div>
                     )}
+                    {msg.key === null && (
+                      <div>
+                        <span className="font-medium text-gray-700">Key:</span>
+                        <span className="ml-2 text-gray-500 italic text-xs">null</span>
+                      </div>
+                    )}
                     {msg.offset !== undefined && (
                       <div>
                         <span className="font-medium text-gray-700">Offset:</span>
@@ -113,6 +119,12 @@ const MessageLog = ({ messages, onClear }) => {
                     </div>
                   )}
 
+                  {msg.value === null && (
+                    <div className="mt-2 text-sm text-gray-500 italic">
+                      Value: null (tombstone)
+                    </div>
+                  )}
+
                   {msg.error && (
                     <div className="mt-2 text-red-600 text-sm">
                       <span className="font-medium">Error:</span> {msg.error}
@@ -11,11 +11,18 @@ const ProducerPanel = ({ topics, onMessageSent }) => {
   const [error, setError] = useState('');
   const [batchMode, setBatchMode] = useState(false);
   const [batchCount, setBatchCount] = useState(5);
+  const [balancer, setBalancer] = useState('hash');
+  const [acks, setAcks] = useState('all');
+  const [compression, setCompression] = useState('none');
+  const [nullKey, setNullKey] = useState(false);
+  const [nullValue, setNullValue] = useState(false);
+
+  const producerOptions = { balancer, acks, compression };
 
   const handleSendMessage = async (e) => {
     e.preventDefault();
-    if (!topic || !value) {
-      setError('토픽과 메시지 값은 필수입니다');
+    if (!topic || (!value && !nullValue)) {
+      setError('토픽과 메시지 값은 필수입니다 (tombstone 제외)');
       return;
     }
 
@@ -24,15 +31,23 @@ const ProducerPanel = ({ topics, onMessageSent }) => {
 
     try {
       const partitionNum = partition ? parseInt(partition) : null;
-      await produceMessage(topic, key, value, partitionNum);
+      const { data } = await produceMessage(
+        topic,
+        nullKey ? undefined : key,
+        nullValue ? undefined : value,
+        partitionNum,
+        { ...producerOptions, nullKey, nullValue }
+      );
 
+      // 브로커가 할당한 파티션, 오프셋, 타임스탬프
       onMessageSent({
         type: 'produced',
         topic,
-        key,
-        value,
-        partition: partitionNum,
-        timestamp: new Date().toISOString(),
+        key: nullKey ? null : key,
+        value: nullValue ? null : value,
+        partition: data.partition,
+        offset: data.offset,
+        timestamp: data.timestamp || new Date().toISOString(),
       });
 
       // 폼 초기화 (토픽과 키는 유지)
@@ -67,16 +82,29 @@ const ProducerPanel = ({ topics, onMessageSent }) => {
         value: `${value || 'Message'} ${i + 1}`,
       }));
 
-      await produceBatchMessages(topic, messages);
+      const { data } = await produceBatchMessages(topic, messages, producerOptions);
 
       onMessageSent({
         type: 'produced',
         topic,
-        value: `${batchCount}개의 배치 메시지 전송됨`,
+        value: `${data.succeeded}개의 배치 메시지 전송됨${data.failed > 0 ? ` (${data.failed}개 실패)` : ''}`,
         timestamp: new Date().toISOString(),
       });
 
-      setError('');
+      // 메시지별 결과 (실패한 메시지는 오류로 표시)
+      data.results.forEach((result) => {
+        onMessageSent({
+          type: result.error ? 'error' : 'produced',
+          topic,
+          key: result.key,
+          partition: result.partition,
+          offset: result.offset,
+          error: result.error,
+          timestamp: result.timestamp || new Date().toISOString(),
+        });
+      });
+
+      setError(data.failed > 0 ? `${data.failed}개 메시지 전송 실패` : '');
     } catch (err) {
       const errorMsg = err.response?.data?.error || err.message || '배치 전송 실패';
       setError(errorMsg);
@@ -144,6 +172,56 @@ const ProducerPanel = ({ topics, onMessageSent }) => {
           </label>
         </div>
 
+        {/* Producer 설정 */}
+        <div className="grid grid-cols-3 gap-2">
+          <div>
+            <label className="block text-sm font-medium text-gray-700 mb-1">
+              Balancer
+            </label>
+            <select
+              value={balancer}
+              onChange={(e) => setBalancer(e.target.value)}
+              className="input-field"
+            >
+              <option value="hash">hash</option>
+              <option value="murmur2">murmur2 (Java)</option>
+              <option value="sticky">sticky (Java)</option>
+              <option value="round-robin">round-robin</option>
+              <option value="least-bytes">least-bytes</option>
+            </select>
+          </div>
+          <div>
+            <label className="block text-sm font-medium text-gray-700 mb-1">
+              Acks
+            </label>
+            <select
+              value={acks}
+              onChange={(e) => setAcks(e.target.value)}
+              className="input-field"
+            >
+              <option value="all">all</option>
+              <option value="leader">leader</option>
+              <option value="none">none</option>
+            </select>
+          </div>
+          <div>
+            <label className="block text-sm font-medium text-gray-700 mb-1">
+              압축
+            </label>
+            <select
+              value={compression}
+              onChange={(e) => setCompression(e.target.value)}
+              className="input-field"
+            >
+              <option value="none">none</option>
+              <option value="gzip">gzip</option>
+              <option value="snappy">snappy</option>
+              <option value="lz4">lz4</option>
+              <option value="zstd">zstd</option>
+            </select>
+          </div>
+        </div>
+
         {batchMode ? (
           /* 배치 모드 UI */
           <div className="space-y-4">
@@ -210,9 +288,32 @@ const ProducerPanel = ({ topics, onMessageSent }) => {
                 onChange={(e) => setKey(e.target.value)}
                 placeholder="메시지 키 (선택사항)"
                 className="input-field"
+                disabled={nullKey}
               />
             </div>
 
+            {/* null 키/값 (빈 문자열과 구분) */}
+            <div className="flex items-center gap-4">
+              <label className="flex items-center gap-2 text-sm font-medium text-gray-700">
+                <input
+                  type="checkbox"
+                  checked={nullKey}
+                  onChange={(e) => setNullKey(e.target.checked)}
+                  className="w-4 h-4"
+                />
+                Null key
+              </label>
+              <label className="flex items-center gap-2 text-sm font-medium text-gray-700">
+                <input
+                  type="checkbox"
+                  checked={nullValue}
+                  onChange={(e) => setNullValue(e.target.checked)}
+                  className="w-4 h-4"
+                />
+                Tombstone (null value)
+              </label>
+            </div>
+
             <div>
               <label className="block text-sm font-medium text-gray-700 mb-1">
                 Value *
@@ -223,7 +324,8 @@ const ProducerPanel = ({ topics, onMessageSent }) => {
                 placeholder="메시지 내용을 입력하세요"
                 className="input-field"
                 rows="4"
-                required
+                required={!nullValue}
+                disabled={nullValue}
               />
             </div>
 
@@ -243,7 +345,7 @@ const ProducerPanel = ({ topics, onMessageSent }) => {
 
             <button
               type="submit"
-              disabled={loading || !topic || !value}
+              disabled={loading || !topic || (!value && !nullValue)}
               className="btn-primary w-full flex items-center justify-center gap-2"
             >
               <Send className="w-4 h-4" />
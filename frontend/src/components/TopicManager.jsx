@@ -1,6 +1,6 @@
 import React, { useState } from 'react';
 import { FolderPlus, Trash2, Info, RefreshCw } from 'lucide-react';
-import { createTopic, deleteTopic, getTopicDetails } from '../services/api';
+import { createTopic, deleteTopic, previewDeleteTopic, getTopicDetails } from '../services/api';
 
 const TopicManager = ({ topics, onTopicsChange }) => {
   const [newTopicName, setNewTopicName] = useState('');
@@ -43,15 +43,22 @@ const TopicManager = ({ topics, onTopicsChange }) => {
   };
 
   const handleDeleteTopic = async (topicName) => {
-    if (!confirm(`정말로 토픽 "${topicName}"을 삭제하시겠습니까?`)) {
-      return;
-    }
-
     setLoading(true);
     setError('');
 
     try {
-      await deleteTopic(topicName);
+      // 삭제 전 영향 확인 (Consumer Group, 최근 produce)
+      const { data } = await previewDeleteTopic(topicName);
+      const { preview } = data;
+
+      const lines = [`정말로 토픽 "${topicName}"을 삭제하시겠습니까?`, `메시지 수: ${preview.messages}`];
+      preview.warnings.forEach((w) => lines.push(`⚠ ${w}`));
+      if (!confirm(lines.join('\n'))) {
+        return;
+      }
+
+      const soft = confirm(`삭제 전에 보관 토픽(${preview.archive_topic})으로 메시지를 복사할까요?`);
+      await deleteTopic(topicName, preview.confirm_token, soft);
 
       if (selectedTopic === topicName) {
         setSelectedTopic(null);
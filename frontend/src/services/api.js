@@ -9,34 +9,118 @@ const api = axios.create({
   },
 });
 
+// 인증 토큰 (localStorage에 저장된 API 토큰 또는 OIDC 액세스 토큰)
+const AUTH_TOKEN_KEY = 'kafkaMonitorToken';
+
+export const getAuthToken = () => localStorage.getItem(AUTH_TOKEN_KEY);
+
+export const setAuthToken = (token) => {
+  if (token) {
+    localStorage.setItem(AUTH_TOKEN_KEY, token);
+  } else {
+    localStorage.removeItem(AUTH_TOKEN_KEY);
+  }
+};
+
+api.interceptors.request.use((config) => {
+  const token = getAuthToken();
+  if (token) {
+    config.headers.Authorization = `Bearer ${token}`;
+  }
+  return config;
+});
+
+export const getCurrentUser = async () => {
+  return api.get('/api/me');
+};
+
 // Producer API
-export const produceMessage = async (topic, key, value, partition = null) => {
+// options: { balancer, acks, compression, timestamp } (생략 시 서버 기본값)
+export const produceMessage = async (topic, key, value, partition = null, options = {}) => {
   const payload = {
     topic,
     key,
     value,
     ...(partition !== null && { partition }),
+    ...options,
   };
   return api.post('/api/produce', payload);
 };
 
-export const produceBatchMessages = async (topic, messages) => {
-  return api.post('/api/produce/batch', { topic, messages });
+export const produceBatchMessages = async (topic, messages, options = {}) => {
+  return api.post('/api/produce/batch', { topic, messages, ...options });
+};
+
+// 트랜잭션 전송 (messages: [{topic, key, value, ...}], options: transactionalId, action, timeoutMs 등)
+export const produceTransaction = async (messages, options = {}) => {
+  return api.post('/api/produce/transaction', { messages, ...options });
+};
+
+// 파일 업로드 전송 (options: format, chunkSize, balancer, acks, compression, CSV 열 매핑 등)
+export const uploadProduceFile = async (topic, file, options = {}) => {
+  const params = new URLSearchParams({ topic, ...options });
+  const form = new FormData();
+  form.append('file', file);
+  return api.post(`/api/produce/upload?${params}`, form);
+};
+
+// 부하 생성 API
+export const startLoadGen = async (job) => {
+  return api.post('/api/loadgen', job);
+};
+
+export const getLoadGenJobs = async () => {
+  return api.get('/api/loadgen');
+};
+
+export const getLoadGenJob = async (id) => {
+  return api.get(`/api/loadgen/${id}`);
+};
+
+export const stopLoadGen = async (id) => {
+  return api.delete(`/api/loadgen/${id}`);
+};
+
+// 토픽 복사 API
+export const startCopyJob = async (job) => {
+  return api.post('/api/copy', job);
+};
+
+export const getCopyJobs = async () => {
+  return api.get('/api/copy');
+};
+
+export const getCopyJob = async (id) => {
+  return api.get(`/api/copy/${id}`);
+};
+
+export const stopCopyJob = async (id) => {
+  return api.delete(`/api/copy/${id}`);
 };
 
 // Consumer API
-export const consumeMessages = async (topic, partition = 0, offset = null) => {
+// isolation: read_uncommitted(기본) 또는 read_committed
+export const consumeMessages = async (topic, partition = 0, offset = null, isolation = null) => {
   const params = new URLSearchParams({ topic, partition });
   if (offset !== null) {
     params.append('offset', offset);
   }
+  if (isolation) {
+    params.append('isolation', isolation);
+  }
   return api.get(`/api/consume?${params}`);
 };
 
 // WebSocket Consumer
 export const createConsumerWebSocket = (topic, group, onMessage, onError) => {
   const wsUrl = API_BASE_URL.replace(/^http/, 'ws');
-  const ws = new WebSocket(`${wsUrl}/api/consume/ws?topic=${topic}&group=${group}`);
+  const params = new URLSearchParams({ topic, group });
+  // 브라우저 WebSocket은 헤더를 지정할 수 없으므로 토큰을 쿼리로 전달
+  const token = getAuthToken();
+  if (token) {
+    params.append('access_token', token);
+  }
+  const ws = new WebSocket(`${wsUrl}/api/consume/ws?${params}`);
 
   ws.onopen = () => {
     console.log('WebSocket connected');
@@ -63,6 +147,29 @@ export const createConsumerWebSocket = (topic, group, onMessage, onError) => {
   return ws;
 };
 
+// DLQ API
+// params: limit, error, originalTopic, status, errorHeader, originalTopicHeader
+export const listDLQTopics = async () => {
+  return api.get('/api/dlq');
+};
+
+export const browseDLQ = async (topic, params = {}) => {
+  return api.get(`/api/dlq/${topic}`, { params });
+};
+
+export const getDLQMessage = async (topic, partition, offset) => {
+  return api.get(`/api/dlq/${topic}/messages/${partition}/${offset}`);
+};
+
+// messages: [{partition, offset, key?, value?, headers?}], options: targetTopic, force, reason 등
+export const redriveDLQMessages = async (topic, messages, options = {}) => {
+  return api.post(`/api/dlq/${topic}/redrive`, { messages, ...options });
+};
+
+export const skipDLQMessages = async (topic, messages, reason = '') => {
+  return api.post(`/api/dlq/${topic}/skip`, { messages, reason });
+};
+
 // Topic Management API
 export const listTopics = async () => {
   return api.get('/api/topics');
@@ -80,8 +187,25 @@ export const getTopicDetails = async (name) => {
   return api.get(`/api/topics/${name}`);
 };
 
-export const deleteTopic = async (name) => {
-  return api.delete(`/api/topics/${name}`);
+// 삭제 영향 확인 및 확인 토큰 발급 (dry-run)
+export const previewDeleteTopic = async (name) => {
+  return api.delete(`/api/topics/${name}`, { params: { dryRun: true } });
+};
+
+export const deleteTopic = async (name, confirmToken, soft = false) => {
+  return api.delete(`/api/topics/${name}`, {
+    params: { confirm: confirmToken, soft },
+  });
+};
+
+// 토픽 백업 파일 내려받기 (params: format, partitions, startOffset, endOffset, startTime, endTime, isolation)
+export const downloadTopicBackup = async (name, params = {}) => {
+  return api.get(`/api/topics/${name}/backup`, { params, responseType: 'blob' });
+};
+
+// 백업 파일 복원 (options: partitioning, timestamps, balancer, acks 등)
+export const restoreTopicBackup = async (topic, file, options = {}) => {
+  return uploadProduceFile(topic, file, { format: 'backup', ...options });
 };
 
 // Metrics API
@@ -101,6 +225,11 @@ export const getClusterMetrics = async () => {
   return api.get('/api/metrics/cluster');
 };
 
+// 지연 카나리 결과 (파티션/브로커별 백분위와 경고)
+export const getCanaryMetrics = async () => {
+  return api.get('/api/metrics/canary');
+};
+
 // Health Check
 export const healthCheck = async () => {
   return api.get('/health');